@@ -0,0 +1,85 @@
+package fastrand64
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"math/bits"
+	"unsafe"
+)
+
+// aesCTRBufSize is how much keystream UnsafeAESCTRRNG generates at a time for Uint64
+const aesCTRBufSize = 1024
+
+// aesCTRZeros is the plaintext, so XORing it with the keystream just yields the keystream
+var aesCTRZeros [aesCTRBufSize]byte
+
+// UnsafeAESCTRRNG is the AES-128-CTR keystream as an UnsafeRNG, using the cpu's AES instructions where crypto/aes
+// has them (AES-NI on amd64, the crypto extensions on arm64). Its output is statistically indistinguishable from
+// random and hard to predict, at several GB/s for Bytes, Read and the Fill functions, for workloads like
+// deduplication or fuzzing corpora where xoshiro's known artifacts are a concern.
+//
+// It is only as unpredictable as its seed, and it is unsafe to call from concurrent goroutines
+type UnsafeAESCTRRNG struct {
+	owner
+	stream cipher.Stream
+	buf    [aesCTRBufSize]byte
+	pos    int
+}
+
+// NewUnsafeAESCTRRNG creates a new Thread unsafe AES-128-CTR generator, the key and starting counter are expanded
+// from the seed with splitmix64
+func NewUnsafeAESCTRRNG(seed int64) *UnsafeAESCTRRNG {
+	return NewUnsafeAESCTRRNGFromSeed(SeedFromInt64(seed))
+}
+
+// NewUnsafeAESCTRRNGFromSeed creates a new Thread unsafe AES-128-CTR generator, the first half of the seed is the
+// key and the second half the starting counter
+func NewUnsafeAESCTRRNGFromSeed(seed Seed) *UnsafeAESCTRRNG {
+	block, err := aes.NewCipher(seed[:16])
+	if err != nil {
+		// a 16 byte key is always valid
+		panic(err)
+	}
+	return &UnsafeAESCTRRNG{stream: cipher.NewCTR(block, seed[16:]), pos: aesCTRBufSize}
+}
+
+// Uint64 returns the next 8 bytes of keystream as a little endian uint64, (not thread safe)
+func (r *UnsafeAESCTRRNG) Uint64() uint64 {
+	r.checkOwner("UnsafeAESCTRRNG")
+	if r.pos == aesCTRBufSize {
+		r.stream.XORKeyStream(r.buf[:], aesCTRZeros[:])
+		r.pos = 0
+	}
+	x := binary.LittleEndian.Uint64(r.buf[r.pos:])
+	r.pos += 8
+	return x
+}
+
+func (r *UnsafeAESCTRRNG) fillBytes(p []byte) {
+	r.checkOwner("UnsafeAESCTRRNG")
+	// serve what's buffered first, then run the keystream straight into p
+	n := copy(p, r.buf[r.pos:])
+	r.pos += n
+	p = p[n:]
+	for len(p) > 0 {
+		m := len(p)
+		if m > aesCTRBufSize {
+			m = aesCTRBufSize
+		}
+		r.stream.XORKeyStream(p[:m], aesCTRZeros[:m])
+		p = p[m:]
+	}
+}
+
+func (r *UnsafeAESCTRRNG) fillUint64s(dst []uint64) {
+	if len(dst) == 0 {
+		return
+	}
+	r.fillBytes(unsafe.Slice((*byte)(unsafe.Pointer(&dst[0])), len(dst)*8))
+	if !littleEndian {
+		for i, x := range dst {
+			dst[i] = bits.ReverseBytes64(x)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package fastrand64
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AESCTR_Keystream(t *testing.T) {
+	seed := SeedFromString(t.Name())
+	block, _ := aes.NewCipher(seed[:16])
+	want := make([]byte, 3000)
+	cipher.NewCTR(block, seed[16:]).XORKeyStream(want, want)
+
+	r := NewUnsafeAESCTRRNGFromSeed(seed)
+	for i := 0; i < len(want)/8; i++ {
+		assert.Equal(t, binary.LittleEndian.Uint64(want[i*8:]), r.Uint64())
+	}
+}
+
+func Test_AESCTR_FillMatchesGeneric(t *testing.T) {
+	for _, skip := range []int{0, 1, 127, 128} {
+		for _, n := range []int{0, 5, 8, 100, 1024, 5000} {
+			a := NewUnsafeAESCTRRNG(7)
+			b := NewUnsafeAESCTRRNG(7)
+			for i := 0; i < skip; i++ {
+				a.Uint64()
+				b.Uint64()
+			}
+			assert.Equal(t, Bytes(plainRNG{b}, make([]byte, n)), Bytes(a, make([]byte, n)), "skip %d n %d", skip, n)
+			assert.Equal(t, b.Uint64(), a.Uint64())
+			assert.Equal(t, FillUint64s(plainRNG{b}, make([]uint64, n)), FillUint64s(a, make([]uint64, n)))
+			assert.Equal(t, b.Uint64(), a.Uint64())
+		}
+	}
+}
+
+func Benchmark_UnsafeAESCTRRNG(b *testing.B) {
+	r := NewUnsafeAESCTRRNG(1)
+	var x uint64
+	for i := 0; i < b.N; i++ {
+		x += r.Uint64()
+	}
+	BenchSink = &x
+}
+
+func Benchmark_UnsafeAESCTRRNG_Bytes_64KB(b *testing.B) {
+	r := NewUnsafeAESCTRRNG(1)
+	buf := make([]byte, 64<<10)
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		Bytes(r, buf)
+	}
+	BenchSink = &buf
+}
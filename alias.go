@@ -0,0 +1,117 @@
+package fastrand64
+
+import "sync/atomic"
+
+// WeightedSampler picks indexes with probability proportional to a slice of weights using Vose's alias method,
+// see Vose, "A linear algorithm for generating random numbers with a given distribution" (1991).
+// Building the table is O(n) and each draw is O(1), two Uint64s and no searching.
+//
+// Like Categorical, Rebuild swaps in a new table atomically without locking readers. Backed by a
+// ThreadsafePoolRNG it is safe to use from concurrent goroutines, backed by anything else it is only as
+// safe as its source
+type WeightedSampler struct {
+	r     UnsafeRNG
+	table atomic.Pointer[aliasTable]
+}
+
+type aliasTable struct {
+	// prob[i] is the chance, out of 2^53, of keeping column i instead of taking alias[i]
+	prob  []uint64
+	alias []int
+}
+
+func newAliasTable(fn string, weights []float64) (*aliasTable, error) {
+	total, err := checkWeights(fn, weights)
+	if err != nil {
+		return nil, err
+	}
+	n := len(weights)
+	t := &aliasTable{prob: make([]uint64, n), alias: make([]int, n)}
+	scaled := make([]float64, n)
+	small := make([]int, 0, n)
+	large := make([]int, 0, n)
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		l := small[len(small)-1]
+		small = small[:len(small)-1]
+		g := large[len(large)-1]
+		large = large[:len(large)-1]
+
+		t.prob[l] = uint64(scaled[l] * (1 << 53))
+		t.alias[l] = g
+		scaled[g] = scaled[g] + scaled[l] - 1
+		if scaled[g] < 1 {
+			small = append(small, g)
+		} else {
+			large = append(large, g)
+		}
+	}
+	// whatever is left over is within rounding error of 1
+	for _, i := range large {
+		t.prob[i] = 1 << 53
+		t.alias[i] = i
+	}
+	for _, i := range small {
+		t.prob[i] = 1 << 53
+		t.alias[i] = i
+	}
+	return t, nil
+}
+
+// NewWeightedSampler creates a WeightedSampler drawing from r. Weights must be finite, non-negative,
+// and not all zero
+func NewWeightedSampler(r UnsafeRNG, weights []float64) (*WeightedSampler, error) {
+	if r == nil {
+		return nil, invalidArgument("NewWeightedSampler: nil source")
+	}
+	t, err := newAliasTable("NewWeightedSampler", weights)
+	if err != nil {
+		return nil, err
+	}
+	w := &WeightedSampler{r: r}
+	w.table.Store(t)
+	return w, nil
+}
+
+// Rebuild replaces the weights, the number of categories may change. Draws already in progress finish
+// with the old table. On error the old table is kept
+func (w *WeightedSampler) Rebuild(weights []float64) error {
+	t, err := newAliasTable("Rebuild", weights)
+	if err != nil {
+		return err
+	}
+	w.table.Store(t)
+	return nil
+}
+
+// Len returns the number of categories in the current table
+func (w *WeightedSampler) Len() int {
+	return len(w.table.Load().prob)
+}
+
+// Next returns a category index with probability proportional to its weight
+func (w *WeightedSampler) Next() int {
+	t := w.table.Load()
+	if p, ok := w.r.(*ThreadsafePoolRNG); ok {
+		r, pool := p.get()
+		i := t.next(r)
+		pool.put(r)
+		return i
+	}
+	return t.next(w.r)
+}
+
+func (t *aliasTable) next(r UnsafeRNG) int {
+	i := Uint64n(r, uint64(len(t.prob)))
+	if r.Uint64()>>11 < t.prob[i] {
+		return int(i)
+	}
+	return t.alias[i]
+}
@@ -0,0 +1,71 @@
+package fastrand64
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewWeightedSampler_Errors(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	for _, w := range [][]float64{nil, {0}, {1, -1}, {math.NaN()}} {
+		s, err := NewWeightedSampler(rng, w)
+		assert.True(t, errors.Is(err, ErrInvalidArgument), "%v", w)
+		assert.Nil(t, s)
+	}
+	s, err := NewWeightedSampler(nil, []float64{1})
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	assert.Nil(t, s)
+}
+
+func Test_WeightedSampler_Next(t *testing.T) {
+	weights := []float64{1, 0, 2, 3, 4}
+	s, err := NewWeightedSampler(NewUnsafeXoshiro256ssRNG(1), weights)
+	assert.NoError(t, err)
+	const n = 200000
+	counts := make([]int, len(weights))
+	for i := 0; i < n; i++ {
+		counts[s.Next()]++
+	}
+	for i, w := range weights {
+		assert.InDelta(t, w/10, float64(counts[i])/n, 0.005, "index %d", i)
+	}
+}
+
+func Test_WeightedSampler_Rebuild(t *testing.T) {
+	s, err := NewWeightedSampler(NewSyncPoolXoshiro256ssRNG(), []float64{1, 1, 1})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10000; i++ {
+				x := s.Next()
+				assert.True(t, x >= 0 && x < 3)
+			}
+		}()
+	}
+	assert.NoError(t, s.Rebuild([]float64{0, 5}))
+	wg.Wait()
+
+	assert.Equal(t, 2, s.Len())
+	assert.Equal(t, 1, s.Next())
+	assert.Error(t, s.Rebuild([]float64{-1}))
+	assert.Equal(t, 2, s.Len())
+}
+
+func Benchmark_WeightedSampler_Next_Parallel(b *testing.B) {
+	s, _ := NewWeightedSampler(NewSyncPoolXoshiro256ssRNG(), []float64{1, 2, 3, 4, 5, 6, 7, 8})
+	b.RunParallel(func(pb *testing.PB) {
+		r := s.Next()
+		for pb.Next() {
+			r = s.Next()
+		}
+		BenchSink = &r
+	})
+}
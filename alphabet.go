@@ -0,0 +1,126 @@
+package fastrand64
+
+import (
+	"math/bits"
+	"unicode/utf8"
+)
+
+// Alphabet is a set of characters that random strings are drawn from. Selection is unbiased for any size:
+// each character takes just enough bits of a Uint64 to cover the alphabet, and out of range values are skipped.
+// Alphabets whose size is a power of two (hex, base64) never skip.
+//
+// Alphabets are immutable, so safe to share between goroutines
+type Alphabet struct {
+	runes []rune
+	ascii []byte // set when every character is a single byte, for the fast path
+	bits  uint
+}
+
+// Predefined alphabets
+var (
+	// AlphabetHex is lower case hexadecimal
+	AlphabetHex = mustAlphabet("0123456789abcdef")
+	// AlphabetBase62 is digits, upper and lower case letters, safe in urls and identifiers
+	AlphabetBase62 = mustAlphabet("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+	// AlphabetURLSafe is the url safe base64 alphabet, its 64 characters need exactly 6 bits each
+	AlphabetURLSafe = mustAlphabet("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_")
+)
+
+func mustAlphabet(chars string) *Alphabet {
+	a, err := NewAlphabet(chars)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// NewAlphabet creates an alphabet from the characters of chars, which must be valid UTF-8, non-empty,
+// and free of duplicates (a duplicated character would be picked more often)
+func NewAlphabet(chars string) (*Alphabet, error) {
+	if !utf8.ValidString(chars) {
+		return nil, invalidArgument("NewAlphabet: invalid UTF-8")
+	}
+	runes := []rune(chars)
+	if len(runes) == 0 {
+		return nil, invalidArgument("NewAlphabet: no characters")
+	}
+	seen := make(map[rune]bool, len(runes))
+	ascii := len(runes) == len(chars)
+	for _, c := range runes {
+		if seen[c] {
+			return nil, invalidArgument("NewAlphabet: duplicate character %q", c)
+		}
+		seen[c] = true
+	}
+	a := &Alphabet{runes: runes, bits: uint(bits.Len(uint(len(runes) - 1)))}
+	if ascii {
+		a.ascii = []byte(chars)
+	}
+	return a, nil
+}
+
+// Len returns the number of characters in the alphabet
+func (a *Alphabet) Len() int {
+	return len(a.runes)
+}
+
+// Append appends n random characters of the alphabet to dst from a thread unsafe RNG, and returns the
+// extended buffer. Reusing dst[:0] across calls makes generation allocation free
+func (a *Alphabet) Append(r UnsafeRNG, dst []byte, n int) []byte {
+	m := uint64(len(a.runes))
+	mask := uint64(1)<<a.bits - 1
+	var x uint64
+	avail := uint(0)
+	for n > 0 {
+		if avail < a.bits {
+			x = r.Uint64()
+			avail = 64
+		}
+		i := x & mask
+		x >>= a.bits
+		avail -= a.bits
+		if i >= m {
+			continue
+		}
+		if a.ascii != nil {
+			dst = append(dst, a.ascii[i])
+		} else {
+			dst = utf8.AppendRune(dst, a.runes[i])
+		}
+		n--
+	}
+	return dst
+}
+
+// String returns n random characters of the alphabet from a thread unsafe RNG
+func (a *Alphabet) String(r UnsafeRNG, n int) string {
+	return string(a.Append(r, make([]byte, 0, n), n))
+}
+
+// AppendString appends n random characters of the alphabet to dst, and returns the extended buffer. Threadsafe
+func (s *ThreadsafePoolRNG) AppendString(dst []byte, a *Alphabet, n int) []byte {
+	r, pool := s.get()
+	dst = a.Append(r, dst, n)
+	pool.put(r)
+	return dst
+}
+
+// StringFrom returns n random characters of the alphabet. Threadsafe
+func (s *ThreadsafePoolRNG) StringFrom(a *Alphabet, n int) string {
+	return string(s.AppendString(make([]byte, 0, n), a, n))
+}
+
+// String returns n random characters of the url safe base64 alphabet. Threadsafe
+func (s *ThreadsafePoolRNG) String(n int) string {
+	return s.StringFrom(AlphabetURLSafe, n)
+}
+
+// Hex returns n random lower case hexadecimal characters. Threadsafe
+func (s *ThreadsafePoolRNG) Hex(n int) string {
+	return s.StringFrom(AlphabetHex, n)
+}
+
+// Base62 returns n random base62 characters. Threadsafe
+func (s *ThreadsafePoolRNG) Base62(n int) string {
+	return s.StringFrom(AlphabetBase62, n)
+}
@@ -0,0 +1,66 @@
+package fastrand64
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewAlphabet_Errors(t *testing.T) {
+	for _, chars := range []string{"", "abca", "\xff"} {
+		a, err := NewAlphabet(chars)
+		assert.True(t, errors.Is(err, ErrInvalidArgument), "%q", chars)
+		assert.Nil(t, a)
+	}
+}
+
+func Test_Alphabet_Uniform(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	a, err := NewAlphabet("abcdefghij")
+	assert.NoError(t, err)
+	s := a.String(rng, 100000)
+	assert.Equal(t, 100000, len(s))
+	for _, c := range "abcdefghij" {
+		assert.InDelta(t, 10000, strings.Count(s, string(c)), 500)
+	}
+}
+
+func Test_Alphabet_Unicode(t *testing.T) {
+	a, err := NewAlphabet("αβγ🎲")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, a.Len())
+	s := a.String(NewUnsafeXoshiro256ssRNG(1), 50)
+	assert.Equal(t, 50, utf8.RuneCountInString(s))
+	for _, c := range s {
+		assert.Contains(t, "αβγ🎲", string(c))
+	}
+
+	one, err := NewAlphabet("x")
+	assert.NoError(t, err)
+	assert.Equal(t, "xxxx", one.String(NewUnsafeXoshiro256ssRNG(1), 4))
+}
+
+func Test_SafeRNG_Strings(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	hex := rng.Hex(32)
+	assert.Regexp(t, "^[0-9a-f]{32}$", hex)
+	assert.Regexp(t, "^[0-9A-Za-z]{20}$", rng.Base62(20))
+	assert.Regexp(t, "^[0-9A-Za-z_-]{20}$", rng.String(20))
+
+	buf := make([]byte, 0, 64)
+	buf = rng.AppendString(buf[:0], AlphabetHex, 16)
+	assert.Equal(t, 16, len(buf))
+	assert.Equal(t, "", rng.StringFrom(AlphabetBase62, 0))
+}
+
+func Benchmark_SyncPoolXoshiro256ssRNG_AppendString_Base62_32(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	buf := make([]byte, 0, 32)
+	for i := 0; i < b.N; i++ {
+		buf = rng.AppendString(buf[:0], AlphabetBase62, 32)
+	}
+	BenchSink = &buf
+}
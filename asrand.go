@@ -0,0 +1,12 @@
+package fastrand64
+
+import "math/rand"
+
+// AsRand wraps the pool in a *math/rand.Rand, for APIs that insist on one, like testing/quick.Config.
+//
+// The pool is already a rand.Source64, so Int63, Uint64, Intn, Float64, Perm, Shuffle and friends are
+// threadsafe through it. Read and Seed are not: the Rand keeps Read's leftover bytes itself, and Seed
+// goes to the pool's ReseedAll
+func AsRand(s *ThreadsafePoolRNG) *rand.Rand {
+	return rand.New(s)
+}
@@ -0,0 +1,26 @@
+package fastrand64
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AsRand(t *testing.T) {
+	r := AsRand(NewSyncPoolXoshiro256ssRNG())
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				x := r.Intn(10)
+				assert.True(t, x >= 0 && x < 10)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 5, len(r.Perm(5)))
+	assert.NotEqual(t, r.Uint64(), r.Uint64())
+}
@@ -0,0 +1,64 @@
+package fastrand64
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// AuditRecord describes one audited probabilistic decision
+type AuditRecord struct {
+	Label   string
+	Inputs  []interface{}
+	Outcome interface{}
+	Time    time.Time
+}
+
+// Auditor makes probabilistic decisions from a thread safe pool and reports a 1-in-N sample of them to a hook,
+// so randomized production behavior (load shedding, bucketing) can be analyzed after an incident.
+//
+// Sampling is by an atomic counter rather than by drawing, so auditing never changes the decisions themselves.
+// The hook is called synchronously on the deciding goroutine, so it should be quick and threadsafe
+type Auditor struct {
+	rng         *ThreadsafePoolRNG
+	sampleEvery uint64
+	hook        func(AuditRecord)
+	count       atomic.Uint64
+}
+
+// NewAuditor creates an Auditor deciding with rng and reporting every sampleEvery-th decision to hook
+func NewAuditor(rng *ThreadsafePoolRNG, sampleEvery uint64, hook func(AuditRecord)) (*Auditor, error) {
+	if rng == nil || hook == nil {
+		return nil, invalidArgument("NewAuditor: nil rng or hook")
+	}
+	if sampleEvery == 0 {
+		return nil, invalidArgument("NewAuditor: sampleEvery must be at least 1")
+	}
+	return &Auditor{rng: rng, sampleEvery: sampleEvery, hook: hook}, nil
+}
+
+// Record counts a decision made elsewhere, and reports it to the hook if it is sampled
+func (a *Auditor) Record(label string, outcome interface{}, inputs ...interface{}) {
+	if a.count.Add(1)%a.sampleEvery != 0 {
+		return
+	}
+	a.hook(AuditRecord{Label: label, Inputs: inputs, Outcome: outcome, Time: time.Now()})
+}
+
+// Bernoulli returns true with probability p, auditing the decision. Threadsafe
+func (a *Auditor) Bernoulli(label string, p float64) bool {
+	x := a.rng.Bernoulli(p)
+	a.Record(label, x, p)
+	return x
+}
+
+// Intn returns an unbiased int in the range [0..n), auditing the decision. Threadsafe
+func (a *Auditor) Intn(label string, n int) int {
+	x := a.rng.Intn(n)
+	a.Record(label, x, n)
+	return x
+}
+
+// Decisions returns how many decisions have been counted, audited or not
+func (a *Auditor) Decisions() uint64 {
+	return a.count.Load()
+}
@@ -0,0 +1,58 @@
+package fastrand64
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewAuditor_Errors(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	hook := func(AuditRecord) {}
+	for _, c := range []struct {
+		rng   *ThreadsafePoolRNG
+		every uint64
+		hook  func(AuditRecord)
+	}{{nil, 1, hook}, {rng, 1, nil}, {rng, 0, hook}} {
+		a, err := NewAuditor(c.rng, c.every, c.hook)
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+		assert.Nil(t, a)
+	}
+}
+
+func Test_Auditor(t *testing.T) {
+	var mu sync.Mutex
+	var records []AuditRecord
+	a, err := NewAuditor(NewSyncPoolXoshiro256ssRNG(), 10, func(r AuditRecord) {
+		mu.Lock()
+		records = append(records, r)
+		mu.Unlock()
+	})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 250; i++ {
+				a.Bernoulli("shed", 0.3)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, uint64(1000), a.Decisions())
+	assert.Equal(t, 100, len(records))
+	assert.Equal(t, "shed", records[0].Label)
+	assert.Equal(t, []interface{}{0.3}, records[0].Inputs)
+	assert.IsType(t, true, records[0].Outcome)
+
+	for i := 0; i < 10; i++ {
+		a.Intn("bucket", 4)
+	}
+	last := records[len(records)-1]
+	assert.Equal(t, "bucket", last.Label)
+	assert.Less(t, last.Outcome.(int), 4)
+}
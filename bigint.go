@@ -0,0 +1,65 @@
+package fastrand64
+
+import "math/big"
+
+// BigIntN returns a uniform pseudorandom *big.Int in the range [0, max) from a thread unsafe RNG.
+//
+// It draws just enough random bytes for max-1 and rejects values that are too big, which happens for less than
+// half the draws. It panics if max <= 0
+func BigIntN(r UnsafeRNG, max *big.Int) *big.Int {
+	if max.Sign() <= 0 {
+		panic("invalid argument to BigIntN")
+	}
+	n := new(big.Int).Sub(max, big.NewInt(1))
+	bitLen := n.BitLen()
+	x := new(big.Int)
+	if bitLen == 0 {
+		return x
+	}
+	buf := make([]byte, (bitLen+7)/8)
+	for {
+		randomBits(r, buf, bitLen)
+		if x.SetBytes(buf).Cmp(max) < 0 {
+			return x
+		}
+	}
+}
+
+// BigIntBits returns a uniform pseudorandom *big.Int in the range [0, 2^n) from a thread unsafe RNG.
+// It panics if n < 0
+func BigIntBits(r UnsafeRNG, n int) *big.Int {
+	if n < 0 {
+		panic("invalid argument to BigIntBits")
+	}
+	buf := make([]byte, (n+7)/8)
+	randomBits(r, buf, n)
+	return new(big.Int).SetBytes(buf)
+}
+
+// randomBits fills buf, big endian, with a random number of n bits, buf must be (n+7)/8 bytes
+func randomBits(r UnsafeRNG, buf []byte, n int) {
+	Bytes(r, buf)
+	if b := uint(n % 8); b != 0 {
+		buf[0] &= 1<<b - 1
+	}
+}
+
+// BigIntN returns a uniform pseudorandom *big.Int in the range [0, max). Threadsafe
+//
+// It panics if max <= 0
+func (s *ThreadsafePoolRNG) BigIntN(max *big.Int) *big.Int {
+	r, pool := s.get()
+	x := BigIntN(r, max)
+	pool.put(r)
+	return x
+}
+
+// BigIntBits returns a uniform pseudorandom *big.Int in the range [0, 2^n). Threadsafe
+//
+// It panics if n < 0
+func (s *ThreadsafePoolRNG) BigIntBits(n int) *big.Int {
+	r, pool := s.get()
+	x := BigIntBits(r, n)
+	pool.put(r)
+	return x
+}
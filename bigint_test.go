@@ -0,0 +1,76 @@
+package fastrand64
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BigIntN(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	// just over a power of two, the worst case for rejection
+	max := big.NewInt(257)
+	counts := make([]int, 257)
+	for i := 0; i < 257*200; i++ {
+		x := BigIntN(rng, max)
+		assert.True(t, x.Sign() >= 0 && x.Cmp(max) < 0)
+		counts[x.Int64()]++
+	}
+	for _, n := range counts {
+		assert.InDelta(t, 200, n, 6*math.Sqrt(200))
+	}
+
+	huge := new(big.Int).Lsh(big.NewInt(3), 1000)
+	highHalf := 0
+	half := new(big.Int).Rsh(huge, 1)
+	for i := 0; i < 1000; i++ {
+		x := BigIntN(rng, huge)
+		assert.True(t, x.Sign() >= 0 && x.Cmp(huge) < 0)
+		if x.Cmp(half) >= 0 {
+			highHalf++
+		}
+	}
+	assert.InDelta(t, 500, highHalf, 6*math.Sqrt(250))
+
+	assert.Equal(t, 0, BigIntN(rng, big.NewInt(1)).Sign())
+	assert.Panics(t, func() { BigIntN(rng, big.NewInt(0)) })
+	assert.Panics(t, func() { BigIntN(rng, big.NewInt(-5)) })
+}
+
+func Test_BigIntBits(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	for _, n := range []int{0, 1, 7, 8, 9, 64, 1000} {
+		topSet := 0
+		for i := 0; i < 1000; i++ {
+			x := BigIntBits(rng, n)
+			assert.True(t, x.BitLen() <= n, "n=%d", n)
+			if n > 0 && x.Bit(n-1) == 1 {
+				topSet++
+			}
+		}
+		if n > 0 {
+			assert.InDelta(t, 500, topSet, 6*math.Sqrt(250), "n=%d", n)
+		}
+	}
+	assert.Panics(t, func() { BigIntBits(rng, -1) })
+}
+
+func Test_SafeRNG_BigInt(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	max := big.NewInt(1000)
+	for i := 0; i < 1000; i++ {
+		assert.True(t, rng.BigIntN(max).Cmp(max) < 0)
+		assert.True(t, rng.BigIntBits(100).BitLen() <= 100)
+	}
+}
+
+func Benchmark_BigIntBits_256(b *testing.B) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	var r *big.Int
+	for i := 0; i < b.N; i++ {
+		r = BigIntBits(rng, 256)
+	}
+	BenchSink = &r
+}
@@ -0,0 +1,154 @@
+package fastrand64
+
+import "math"
+
+// binomialBTRDMin is the mean at which Binomial switches from inversion to BTRD,
+// inversion costs about n*p steps per value so it only wins for small means
+const binomialBTRDMin = 10
+
+// Binomial returns the number of successes in n independent trials that each succeed with probability p,
+// from a thread unsafe RNG
+//
+// Small means use sequential inversion, larger means use BTRD from
+// Hörmann, "The generation of binomial random variates" (1993).
+// It panics if n < 0 or p is outside [0, 1]
+func Binomial(r UnsafeRNG, n int, p float64) int {
+	if n < 0 || !(p >= 0 && p <= 1) {
+		panic("invalid argument to Binomial")
+	}
+	if p > 0.5 {
+		return n - Binomial(r, n, 1-p)
+	}
+	if n == 0 || p == 0 {
+		return 0
+	}
+	if float64(n)*p < binomialBTRDMin {
+		return binomialInversion(r, n, p)
+	}
+	return binomialBTRD(r, n, p)
+}
+
+func binomialInversion(r UnsafeRNG, n int, p float64) int {
+	q := 1 - p
+	s := p / q
+	a := float64(n+1) * s
+	start := math.Exp(float64(n) * math.Log1p(-p))
+	// rounding can walk the search past any sensible value, in which case just start over
+	bound := int(math.Min(float64(n), float64(n)*p+10*math.Sqrt(float64(n)*p*q+1)))
+	for {
+		f := start
+		u := Float64(r)
+		k := 0
+		for u > f {
+			u -= f
+			k++
+			if k > bound {
+				break
+			}
+			f *= a/float64(k) - s
+		}
+		if k <= bound {
+			return k
+		}
+	}
+}
+
+// binomialFc is the Stirling series correction, log(k!) - ((k+0.5)log(k+1) - (k+1) + log(sqrt(2 pi)))
+func binomialFc(k float64) float64 {
+	if k < 10 {
+		return binomialFcTable[int(k)]
+	}
+	k1 := 1 / (k + 1)
+	k2 := k1 * k1
+	return (1.0/12 - (1.0/360-1.0/1260*k2)*k2) * k1
+}
+
+var binomialFcTable = [10]float64{
+	0.08106146679532726, 0.04134069595540929, 0.02767792568499834, 0.02079067210376509,
+	0.01664469118982119, 0.01387612882307075, 0.01189670994589177, 0.01041126526197209,
+	0.009255462182712733, 0.008330563433362871,
+}
+
+func binomialBTRD(r UnsafeRNG, n int, p float64) int {
+	fn := float64(n)
+	m := math.Floor((fn + 1) * p)
+	rr := p / (1 - p)
+	nr := (fn + 1) * rr
+	npq := fn * p * (1 - p)
+	spq := math.Sqrt(npq)
+	b := 1.15 + 2.53*spq
+	a := -0.0873 + 0.0248*b + 0.01*p
+	c := fn*p + 0.5
+	alpha := (2.83 + 5.1/b) * spq
+	vr := 0.92 - 4.2/b
+	urvr := 0.86 * vr
+
+	for {
+		v := Float64(r)
+		if v <= urvr {
+			u := v/vr - 0.43
+			return int(math.Floor((2*a/(0.5-math.Abs(u))+b)*u + c))
+		}
+		var u float64
+		if v >= vr {
+			u = Float64(r) - 0.5
+		} else {
+			u = v/vr - 0.93
+			u = math.Copysign(0.5, u) - u
+			v = Float64(r) * vr
+		}
+
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + c)
+		if k < 0 || k > fn {
+			continue
+		}
+		v = v * alpha / (a/(us*us) + b)
+		km := math.Abs(k - m)
+
+		if km <= 15 {
+			// close to the mode, so evaluate f(k)/f(m) with the recurrence
+			f := 1.0
+			if m < k {
+				for i := m + 1; i <= k; i++ {
+					f *= nr/i - rr
+				}
+			} else if m > k {
+				for i := k + 1; i <= m; i++ {
+					v *= nr/i - rr
+				}
+			}
+			if v <= f {
+				return int(k)
+			}
+			continue
+		}
+
+		// squeeze on log(v) before paying for the full Stirling comparison
+		v = math.Log(v)
+		rho := (km / npq) * (((km/3+0.625)*km+1.0/6)/npq + 0.5)
+		t := -km * km / (2 * npq)
+		if v < t-rho {
+			return int(k)
+		}
+		if v > t+rho {
+			continue
+		}
+		nm := fn - m + 1
+		h := (m+0.5)*math.Log((m+1)/(rr*nm)) + binomialFc(m) + binomialFc(fn-m)
+		nk := fn - k + 1
+		if v <= h+(fn+1)*math.Log(nm/nk)+(k+0.5)*math.Log(nk*rr/(k+1))-binomialFc(k)-binomialFc(fn-k) {
+			return int(k)
+		}
+	}
+}
+
+// Binomial returns the number of successes in n independent trials that each succeed with probability p. Threadsafe
+//
+// It panics if n < 0 or p is outside [0, 1]
+func (s *ThreadsafePoolRNG) Binomial(n int, p float64) int {
+	r, pool := s.get()
+	x := Binomial(r, n, p)
+	pool.put(r)
+	return x
+}
@@ -0,0 +1,55 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Binomial(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	cases := []struct {
+		n int
+		p float64
+	}{{10, 0.5}, {100, 0.05}, {100, 0.3}, {1000, 0.5}, {1000, 0.9}, {1000000, 0.001}, {5, 1}}
+	for _, c := range cases {
+		expectedMean := float64(c.n) * c.p
+		expectedVar := expectedMean * (1 - c.p)
+		mean, variance := sampleMoments(200000, func() float64 {
+			k := Binomial(rng, c.n, c.p)
+			assert.GreaterOrEqual(t, k, 0)
+			assert.LessOrEqual(t, k, c.n)
+			return float64(k)
+		})
+		assert.InDelta(t, expectedMean, mean, 5*math.Sqrt(expectedVar/200000)+1e-9, "n=%v p=%v", c.n, c.p)
+		assert.InDelta(t, expectedVar, variance, 0.03*expectedVar+1e-9, "n=%v p=%v", c.n, c.p)
+	}
+	assert.Equal(t, 0, Binomial(rng, 0, 0.5))
+	assert.Equal(t, 0, Binomial(rng, 10, 0))
+}
+
+func Test_SafeRNG_Binomial(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	mean, _ := sampleMoments(100000, func() float64 { return float64(rng.Binomial(50, 0.4)) })
+	assert.InDelta(t, 20.0, mean, 0.1)
+	assert.Panics(t, func() { rng.Binomial(-1, 0.5) })
+	assert.Panics(t, func() { rng.Binomial(10, 1.5) })
+}
+
+func Test_Geometric(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	for _, p := range []float64{0.01, 0.2, 0.5, 0.9} {
+		expectedMean := (1 - p) / p
+		mean, _ := sampleMoments(200000, func() float64 { return float64(Geometric(rng, p)) })
+		assert.InDelta(t, expectedMean, mean, 0.02*expectedMean, "p=%v", p)
+	}
+	assert.Equal(t, 0, Geometric(rng, 1))
+}
+
+func Test_SafeRNG_Geometric(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	mean, _ := sampleMoments(100000, func() float64 { return float64(rng.Geometric(0.25)) })
+	assert.InDelta(t, 3.0, mean, 0.1)
+	assert.Panics(t, func() { rng.Geometric(0) })
+}
@@ -0,0 +1,87 @@
+package fastrand64
+
+// Bernoulli returns true with probability p from a thread unsafe RNG
+//
+// It compares 53 random bits against a threshold instead of doing float math, and panics if p is outside [0, 1]
+func Bernoulli(r UnsafeRNG, p float64) bool {
+	if !(p >= 0 && p <= 1) {
+		panic("invalid argument to Bernoulli")
+	}
+	return r.Uint64()>>11 < uint64(p*(1<<53))
+}
+
+// BoolSource hands out random bools one buffered bit at a time, so 64 calls cost a single Uint64.
+// It is as unsafe as the RNG it wraps
+type BoolSource struct {
+	r    UnsafeRNG
+	bits uint64
+	n    uint
+}
+
+// NewBoolSource creates a BoolSource drawing from a thread unsafe RNG
+func NewBoolSource(r UnsafeRNG) *BoolSource {
+	return &BoolSource{r: r}
+}
+
+// Bool returns a random bool, (not thread safe)
+func (b *BoolSource) Bool() bool {
+	if b.n == 0 {
+		b.bits = b.r.Uint64()
+		b.n = 64
+	}
+	x := b.bits&1 == 1
+	b.bits >>= 1
+	b.n--
+	return x
+}
+
+// bitBuffer holds the leftover bits for ThreadsafePoolRNG.Bool and the narrow integer methods, it lives in its own
+// pool so most calls don't need to check out a generator at all
+type bitBuffer struct {
+	bits uint64 // unused bits are at the top
+	n    uint
+}
+
+// take returns the next n buffered bits (n is 1 to 64) in the low bits of the result, drawing a fresh word
+// from pools when fewer than n are left
+func (b *bitBuffer) take(pools *rngPools, n uint) uint64 {
+	if b.n < n {
+		r := pools.get()
+		b.bits = r.Uint64()
+		pools.put(r)
+		b.n = 64
+	}
+	x := b.bits >> (64 - n)
+	b.bits <<= n
+	b.n -= n
+	return x
+}
+
+// takeBits returns the next n bits from one of the pool's bit buffers
+func (s *ThreadsafePoolRNG) takeBits(n uint) uint64 {
+	pools := s.pools.Load()
+	b, _ := pools.bitPool.Get().(*bitBuffer)
+	if b == nil {
+		b = &bitBuffer{}
+	}
+	x := b.take(pools, n)
+	pools.bitPool.Put(b)
+	return x
+}
+
+// Bool returns a random bool. Threadsafe
+//
+// Bits are buffered, so only one call in 64 checks a generator out of the pool
+func (s *ThreadsafePoolRNG) Bool() bool {
+	return s.takeBits(1) == 1
+}
+
+// Bernoulli returns true with probability p. Threadsafe
+//
+// It panics if p is outside [0, 1]
+func (s *ThreadsafePoolRNG) Bernoulli(p float64) bool {
+	r, pool := s.get()
+	x := Bernoulli(r, p)
+	pool.put(r)
+	return x
+}
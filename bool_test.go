@@ -0,0 +1,68 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Bernoulli(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	for _, p := range []float64{0.01, 0.25, 0.5, 0.99} {
+		hits := 0
+		for i := 0; i < 100000; i++ {
+			if Bernoulli(rng, p) {
+				hits++
+			}
+		}
+		assert.InDelta(t, p, float64(hits)/100000, 0.005, "p=%v", p)
+	}
+	assert.False(t, Bernoulli(constRNG(0), 0))
+	assert.True(t, Bernoulli(constRNG(math.MaxUint64), 1))
+	assert.Panics(t, func() { Bernoulli(rng, 1.1) })
+}
+
+func Test_BoolSource(t *testing.T) {
+	b := NewBoolSource(constRNG(0x5))
+	assert.True(t, b.Bool())
+	assert.False(t, b.Bool())
+	assert.True(t, b.Bool())
+	for i := 3; i < 64; i++ {
+		assert.False(t, b.Bool())
+	}
+	// the next word is drawn after 64 bits
+	assert.True(t, b.Bool())
+}
+
+func Test_SafeRNG_Bool(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	trues := 0
+	for i := 0; i < 100000; i++ {
+		if rng.Bool() {
+			trues++
+		}
+	}
+	assert.InDelta(t, 0.5, float64(trues)/100000, 0.01)
+}
+
+func Test_SafeRNG_Bernoulli(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	hits := 0
+	for i := 0; i < 100000; i++ {
+		if rng.Bernoulli(0.1) {
+			hits++
+		}
+	}
+	assert.InDelta(t, 0.1, float64(hits)/100000, 0.005)
+	assert.Panics(t, func() { rng.Bernoulli(-0.1) })
+}
+
+func Benchmark_SyncPoolXoshiro256ssRNG_Bool_Serial(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	var r bool
+	for i := 0; i < b.N; i++ {
+		r = rng.Bool()
+	}
+	BenchSink = &r
+}
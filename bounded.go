@@ -0,0 +1,51 @@
+package fastrand64
+
+import "math/bits"
+
+// Uint64n returns an unbiased pseudorandom uint64 in the range [0..n) from a thread unsafe RNG
+//
+// Uses Lemire's multiply and reject method, see https://arxiv.org/abs/1805.10941, which almost never
+// needs a second draw. It panics if n == 0
+func Uint64n(r UnsafeRNG, n uint64) uint64 {
+	if n == 0 {
+		panic("invalid argument to Uint64n")
+	}
+	hi, lo := bits.Mul64(r.Uint64(), n)
+	if lo < n {
+		threshold := -n % n
+		for lo < threshold {
+			hi, lo = bits.Mul64(r.Uint64(), n)
+		}
+	}
+	return hi
+}
+
+// Intn returns an unbiased pseudorandom int in the range [0..n) from a thread unsafe RNG
+//
+// It panics if n <= 0
+func Intn(r UnsafeRNG, n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	return int(Uint64n(r, uint64(n)))
+}
+
+// Uint64n returns an unbiased pseudorandom uint64 in the range [0..n). Threadsafe
+//
+// It panics if n == 0
+func (s *ThreadsafePoolRNG) Uint64n(n uint64) uint64 {
+	r, pool := s.get()
+	x := Uint64n(r, n)
+	pool.put(r)
+	return x
+}
+
+// Intn returns an unbiased pseudorandom int in the range [0..n). Threadsafe
+//
+// It panics if n <= 0
+func (s *ThreadsafePoolRNG) Intn(n int) int {
+	r, pool := s.get()
+	x := Intn(r, n)
+	pool.put(r)
+	return x
+}
@@ -0,0 +1,33 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Uint64n(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	counts := make([]int, 7)
+	for i := 0; i < 70000; i++ {
+		counts[Uint64n(rng, 7)]++
+	}
+	for _, c := range counts {
+		assert.InDelta(t, 10000, c, 500)
+	}
+	assert.Equal(t, uint64(0), Uint64n(rng, 1))
+	assert.Less(t, Uint64n(rng, math.MaxUint64), uint64(math.MaxUint64))
+	assert.Panics(t, func() { Uint64n(rng, 0) })
+}
+
+func Test_SafeRNG_Intn(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for i := 0; i < 4096; i++ {
+		x := rng.Intn(10)
+		assert.True(t, x >= 0 && x < 10)
+		assert.Less(t, rng.Uint64n(3), uint64(3))
+	}
+	assert.Panics(t, func() { rng.Intn(0) })
+	assert.Panics(t, func() { rng.Intn(-1) })
+}
@@ -0,0 +1,73 @@
+package fastrand64
+
+import "errors"
+
+// ErrBudgetExceeded is returned once a BudgetSource has served all of the draws it was allowed
+var ErrBudgetExceeded = errors.New("fastrand64: draw budget exceeded")
+
+// BudgetSource wraps an UnsafeRNG and enforces a maximum number of draws, to catch runaway random consumption
+// in algorithms with rejection loops. Each draw is one Uint64, or 8 bytes of Read.
+//
+// Uint64 can't return an error, so past the budget it keeps serving values and calls onExceeded once,
+// which may panic to abort the runaway caller. Read returns ErrBudgetExceeded instead.
+// It is as unsafe as the RNG it wraps
+type BudgetSource struct {
+	r          UnsafeRNG
+	maxDraws   uint64
+	draws      uint64
+	onExceeded func(draws uint64)
+}
+
+// NewBudgetSource creates a BudgetSource allowing maxDraws draws from r, onExceeded may be nil
+func NewBudgetSource(r UnsafeRNG, maxDraws uint64, onExceeded func(draws uint64)) *BudgetSource {
+	return &BudgetSource{r: r, maxDraws: maxDraws, onExceeded: onExceeded}
+}
+
+// Uint64 returns the next value from the wrapped RNG, calling onExceeded on the first draw past the budget
+func (b *BudgetSource) Uint64() uint64 {
+	b.draws++
+	if b.draws == b.maxDraws+1 && b.onExceeded != nil {
+		b.onExceeded(b.draws)
+	}
+	return b.r.Uint64()
+}
+
+// Read fills p with random bytes in the same little endian order as Bytes, until the budget runs out.
+// It returns ErrBudgetExceeded, along with the bytes that did fit, once the budget is spent
+func (b *BudgetSource) Read(p []byte) (int, error) {
+	i := 0
+	for i < len(p) {
+		if b.draws >= b.maxDraws {
+			return i, ErrBudgetExceeded
+		}
+		b.draws++
+		x := b.r.Uint64()
+		for j := 0; j < 8 && i < len(p); j++ {
+			p[i] = byte(x)
+			x >>= 8
+			i++
+		}
+	}
+	return i, nil
+}
+
+// Draws returns how many draws have been made, including any past the budget
+func (b *BudgetSource) Draws() uint64 {
+	return b.draws
+}
+
+// Remaining returns how many draws are left in the budget
+func (b *BudgetSource) Remaining() uint64 {
+	if b.draws >= b.maxDraws {
+		return 0
+	}
+	return b.maxDraws - b.draws
+}
+
+// Err returns ErrBudgetExceeded once more draws than the budget allows have been made
+func (b *BudgetSource) Err() error {
+	if b.draws > b.maxDraws {
+		return ErrBudgetExceeded
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BudgetSource_Uint64(t *testing.T) {
+	var exceededAt uint64
+	calls := 0
+	b := NewBudgetSource(NewUnsafeRandRNG(1), 3, func(draws uint64) {
+		exceededAt = draws
+		calls++
+	})
+	expected := NewUnsafeRandRNG(1)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, expected.Uint64(), b.Uint64())
+	}
+	assert.NoError(t, b.Err())
+	assert.Equal(t, uint64(0), b.Remaining())
+
+	assert.Equal(t, expected.Uint64(), b.Uint64())
+	b.Uint64()
+	assert.Equal(t, ErrBudgetExceeded, b.Err())
+	assert.Equal(t, uint64(4), exceededAt)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, uint64(5), b.Draws())
+}
+
+func Test_BudgetSource_Panics(t *testing.T) {
+	b := NewBudgetSource(NewUnsafeRandRNG(1), 10, func(draws uint64) { panic("runaway") })
+	assert.Panics(t, func() {
+		for {
+			b.Uint64()
+		}
+	})
+}
+
+func Test_BudgetSource_Read(t *testing.T) {
+	b := NewBudgetSource(NewUnsafeRandRNG(1), 2, nil)
+	p := make([]byte, 20)
+	n, err := b.Read(p)
+	assert.Equal(t, ErrBudgetExceeded, err)
+	assert.Equal(t, 16, n)
+	assert.Equal(t, Bytes(NewUnsafeRandRNG(1), make([]byte, 16)), p[:16])
+
+	b = NewBudgetSource(NewUnsafeRandRNG(1), 2, nil)
+	n, err = b.Read(p[:12])
+	assert.NoError(t, err)
+	assert.Equal(t, 12, n)
+	assert.Equal(t, uint64(0), b.Remaining())
+}
@@ -0,0 +1,48 @@
+package fastrand64
+
+import (
+	"slices"
+	"sync"
+)
+
+// chunkSize is how many random bytes the streaming writers generate per pool checkout
+const chunkSize = 32 * 1024
+
+// chunkPool recycles the scratch buffers used to stream random bytes, so writing a payload doesn't allocate
+var chunkPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, chunkSize)
+		return &b
+	},
+}
+
+func getChunk() *[]byte {
+	return chunkPool.Get().(*[]byte)
+}
+
+func putChunk(b *[]byte) {
+	chunkPool.Put(b)
+}
+
+// AppendBytes appends n random bytes to dst from a thread unsafe RNG, in the same little endian order as Bytes,
+// and returns the extended buffer. Reusing dst[:0] across calls makes generation allocation free
+func AppendBytes(r UnsafeRNG, dst []byte, n int) []byte {
+	dst = slices.Grow(dst, n)
+	m := len(dst)
+	dst = dst[:m+n]
+	Bytes(r, dst[m:])
+	return dst
+}
+
+// AppendBytes appends n random bytes to dst and returns the extended buffer. Threadsafe
+//
+// Unlike Bytes this doesn't allocate once dst has the capacity, so prefer it on hot paths
+func (s *ThreadsafePoolRNG) AppendBytes(dst []byte, n int) []byte {
+	r, pool := s.get()
+	dst = AppendBytes(r, dst, n)
+	pool.put(r)
+	if s.stats != nil {
+		s.stats.bytes.Add(uint64(n))
+	}
+	return dst
+}
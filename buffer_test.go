@@ -0,0 +1,31 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AppendBytes(t *testing.T) {
+	dst := AppendBytes(NewUnsafeRandRNG(1), []byte("abc"), 13)
+	assert.Equal(t, "abc", string(dst[:3]))
+	assert.Equal(t, Bytes(NewUnsafeRandRNG(1), make([]byte, 13)), dst[3:])
+
+	rng := NewSyncPoolXoshiro256ssRNG()
+	buf := make([]byte, 0, 256)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = rng.AppendBytes(buf[:0], 256)
+	})
+	assert.Equal(t, 0.0, allocs)
+	assert.Equal(t, 256, len(buf))
+}
+
+func Benchmark_SyncPoolXoshiro256ssRNG_AppendBytes_1024bytes(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	buf := make([]byte, 0, 1024)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = rng.AppendBytes(buf[:0], 1024)
+	}
+	BenchSink = &buf
+}
@@ -0,0 +1,61 @@
+package fastrand64
+
+// defaultBufferedWords is the BufferedRNG block size when none is given, 4KB of output per pool checkout
+const defaultBufferedWords = 512
+
+// BufferedRNG serves random numbers from a block pre-generated with a single pool checkout, so code that draws
+// in bursts pays for the pool once per block rather than once per call. It is not threadsafe, give each
+// goroutine its own, and it is usable anywhere an UnsafeRNG is.
+//
+// Values already in the buffer are not thrown away by InvalidateAndReseed on the pool, call Discard for that
+type BufferedRNG struct {
+	pool *ThreadsafePoolRNG
+	buf  []uint64
+	i    int
+}
+
+// NewBufferedRNG creates a BufferedRNG that refills size uint64s at a time from pool, size <= 0 means 512
+func NewBufferedRNG(pool *ThreadsafePoolRNG, size int) *BufferedRNG {
+	if size <= 0 {
+		size = defaultBufferedWords
+	}
+	b := &BufferedRNG{pool: pool, buf: make([]uint64, size)}
+	b.i = len(b.buf)
+	return b
+}
+
+// refill generates a whole new block
+func (b *BufferedRNG) refill() {
+	b.pool.FillUint64s(b.buf)
+	b.i = 0
+}
+
+// Uint64 returns pseudorandom uint64, (not thread safe)
+func (b *BufferedRNG) Uint64() uint64 {
+	if b.i == len(b.buf) {
+		b.refill()
+	}
+	x := b.buf[b.i]
+	b.i++
+	return x
+}
+
+// Buffered returns how many uint64s are left before the next refill
+func (b *BufferedRNG) Buffered() int {
+	return len(b.buf) - b.i
+}
+
+// Discard drops the buffered values, so the next call draws a fresh block from the pool
+func (b *BufferedRNG) Discard() {
+	b.i = len(b.buf)
+}
+
+// Read fills a []byte array with random bytes, in the same little endian order as Bytes, (not thread safe)
+//
+// Reads of a block or more go straight to the pool, they would gain nothing from the buffer
+func (b *BufferedRNG) Read(p []byte) []byte {
+	if len(p) >= len(b.buf)*8 {
+		return b.pool.Read(p)
+	}
+	return Bytes(b, p)
+}
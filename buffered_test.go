@@ -0,0 +1,60 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BufferedRNG_Uint64(t *testing.T) {
+	pool := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(1) }, WithStats())
+	b := NewBufferedRNG(pool, 16)
+	assert.Equal(t, 0, b.Buffered())
+
+	// a block comes from a single generator, in order
+	expected := NewUnsafeXoshiro256ssRNG(1)
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, expected.Uint64(), b.Uint64())
+	}
+	assert.Equal(t, 0, b.Buffered())
+	assert.Equal(t, uint64(1), pool.Stats().Gets)
+
+	for i := 0; i < 16*9; i++ {
+		b.Uint64()
+	}
+	assert.Equal(t, uint64(10), pool.Stats().Gets)
+
+	b.Uint64()
+	assert.Equal(t, 15, b.Buffered())
+	b.Discard()
+	assert.Equal(t, 0, b.Buffered())
+	b.Uint64()
+	assert.Equal(t, uint64(12), pool.Stats().Gets)
+
+	assert.Equal(t, defaultBufferedWords, len(NewBufferedRNG(pool, 0).buf))
+}
+
+func Test_BufferedRNG_Read(t *testing.T) {
+	pool := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(1) })
+	b := NewBufferedRNG(pool, 4)
+	expected := Bytes(NewUnsafeXoshiro256ssRNG(1), make([]byte, 16))
+	assert.Equal(t, expected, b.Read(make([]byte, 16)))
+
+	// reads of a block or more skip the buffer
+	left := b.Buffered()
+	p := b.Read(make([]byte, 32))
+	assert.Equal(t, left, b.Buffered())
+	assert.NotEqual(t, make([]byte, 32), p)
+
+	// and it works wherever an UnsafeRNG does
+	assert.Less(t, Intn(b, 10), 10)
+}
+
+func Benchmark_BufferedRNG_Uint64(b *testing.B) {
+	rng := NewBufferedRNG(NewSyncPoolXoshiro256ssRNG(), 0)
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
+}
@@ -0,0 +1,104 @@
+package fastrand64
+
+import (
+	"math"
+	"sort"
+	"sync/atomic"
+)
+
+// Categorical picks indexes with probability proportional to a slice of weights, by binary searching
+// a cumulative table, so each draw is O(log n).
+//
+// The table can be replaced with Rebuild while other goroutines are drawing, the new table is built off the
+// hot path and swapped in atomically, so readers never lock. Backed by a ThreadsafePoolRNG it is safe to
+// use from concurrent goroutines, backed by anything else it is only as safe as its source
+type Categorical struct {
+	r     UnsafeRNG
+	table atomic.Pointer[categoricalTable]
+}
+
+type categoricalTable struct {
+	cdf  []float64
+	last int // the last index with a positive weight, in case rounding pushes a draw past the end
+}
+
+// checkWeights returns the sum of the weights, or an error if they can't be sampled from
+func checkWeights(fn string, weights []float64) (float64, error) {
+	if len(weights) == 0 {
+		return 0, invalidArgument("%s: no weights", fn)
+	}
+	total := 0.0
+	for i, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 0) {
+			return 0, invalidArgument("%s: weight %d is %v", fn, i, w)
+		}
+		total += w
+	}
+	if !(total > 0) || math.IsInf(total, 0) {
+		return 0, invalidArgument("%s: weights sum to %v", fn, total)
+	}
+	return total, nil
+}
+
+func newCategoricalTable(fn string, weights []float64) (*categoricalTable, error) {
+	if _, err := checkWeights(fn, weights); err != nil {
+		return nil, err
+	}
+	t := &categoricalTable{cdf: make([]float64, len(weights))}
+	sum := 0.0
+	for i, w := range weights {
+		sum += w
+		t.cdf[i] = sum
+		if w > 0 {
+			t.last = i
+		}
+	}
+	return t, nil
+}
+
+// NewCategorical creates a Categorical drawing from r. Weights must be finite, non-negative, and not all zero
+func NewCategorical(r UnsafeRNG, weights []float64) (*Categorical, error) {
+	if r == nil {
+		return nil, invalidArgument("NewCategorical: nil source")
+	}
+	t, err := newCategoricalTable("NewCategorical", weights)
+	if err != nil {
+		return nil, err
+	}
+	c := &Categorical{r: r}
+	c.table.Store(t)
+	return c, nil
+}
+
+// Rebuild replaces the weights, the number of categories may change. Draws already in progress finish
+// with the old table. On error the old table is kept
+func (c *Categorical) Rebuild(weights []float64) error {
+	t, err := newCategoricalTable("Rebuild", weights)
+	if err != nil {
+		return err
+	}
+	c.table.Store(t)
+	return nil
+}
+
+// Len returns the number of categories in the current table
+func (c *Categorical) Len() int {
+	return len(c.table.Load().cdf)
+}
+
+// Next returns a category index with probability proportional to its weight
+func (c *Categorical) Next() int {
+	t := c.table.Load()
+	var u float64
+	if p, ok := c.r.(*ThreadsafePoolRNG); ok {
+		u = p.Float64()
+	} else {
+		u = Float64(c.r)
+	}
+	x := u * t.cdf[len(t.cdf)-1]
+	i := sort.Search(len(t.cdf), func(i int) bool { return t.cdf[i] > x })
+	if i > t.last {
+		return t.last
+	}
+	return i
+}
@@ -0,0 +1,57 @@
+package fastrand64
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewCategorical_Errors(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	for _, w := range [][]float64{nil, {0, 0}, {1, -1}, {1, math.NaN()}, {math.Inf(1)}} {
+		c, err := NewCategorical(rng, w)
+		assert.True(t, errors.Is(err, ErrInvalidArgument), "%v", w)
+		assert.Nil(t, c)
+	}
+}
+
+func Test_Categorical_Next(t *testing.T) {
+	c, err := NewCategorical(NewUnsafeXoshiro256ssRNG(1), []float64{1, 0, 3})
+	assert.NoError(t, err)
+	counts := make([]int, 3)
+	for i := 0; i < 100000; i++ {
+		counts[c.Next()]++
+	}
+	assert.Equal(t, 0, counts[1])
+	assert.InDelta(t, 0.25, float64(counts[0])/100000, 0.01)
+
+	c, _ = NewCategorical(constRNG(math.MaxUint64), []float64{1, 1, 0})
+	assert.Equal(t, 1, c.Next())
+}
+
+func Test_Categorical_Rebuild(t *testing.T) {
+	c, err := NewCategorical(NewSyncPoolXoshiro256ssRNG(), []float64{1, 1})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10000; i++ {
+				x := c.Next()
+				assert.True(t, x >= 0 && x < 4)
+			}
+		}()
+	}
+	assert.NoError(t, c.Rebuild([]float64{0, 0, 0, 1}))
+	wg.Wait()
+
+	assert.Equal(t, 4, c.Len())
+	assert.Equal(t, 3, c.Next())
+	assert.Error(t, c.Rebuild([]float64{}))
+	assert.Equal(t, 4, c.Len())
+}
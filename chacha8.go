@@ -0,0 +1,16 @@
+package fastrand64
+
+import randv2 "math/rand/v2"
+
+// NewUnsafeChaCha8RNG creates a new Thread unsafe ChaCha8 generator using the golang math/rand/v2 implementation
+//
+// ChaCha8 is a lot slower than xoshiro256**, but its output is hard to predict even after observing lots of it.
+// The 32 byte key is expanded from the seed with splitmix64, so it is only as unpredictable as the seed itself
+func NewUnsafeChaCha8RNG(seed int64) *randv2.ChaCha8 {
+	return randv2.NewChaCha8(SeedFromInt64(seed))
+}
+
+// NewUnsafeChaCha8RNGFromSeed creates a new Thread unsafe ChaCha8 generator keyed directly by the 256 bit seed
+func NewUnsafeChaCha8RNGFromSeed(seed Seed) *randv2.ChaCha8 {
+	return randv2.NewChaCha8(seed)
+}
@@ -0,0 +1,124 @@
+package fastrand64
+
+import (
+	"math/bits"
+	"time"
+)
+
+// Profile describes what matters most when ChooseGenerator picks a backing generator
+type Profile int
+
+const (
+	// MaxSpeed picks the fastest generator that passes the quality smoke check
+	MaxSpeed Profile = iota
+	// Balanced picks the fastest generator with at least 256 bits of state
+	Balanced
+	// HardToPredict picks the fastest generator whose output can't be predicted by observing it
+	HardToPredict
+)
+
+type generatorCandidate struct {
+	name          string
+	stateBits     int
+	hardToPredict bool
+	newFn         func(seed int64) UnsafeRNG
+}
+
+var generatorCandidates = []generatorCandidate{
+	{"wyrand", 64, false, func(seed int64) UnsafeRNG { return NewUnsafeWyrandRNG(seed) }},
+	{"xoshiro256ss", 256, false, func(seed int64) UnsafeRNG { return NewUnsafeXoshiro256ssRNG(seed) }},
+	{"chacha8", 256, true, func(seed int64) UnsafeRNG { return NewUnsafeChaCha8RNG(seed) }},
+	{"aesctr", 256, true, func(seed int64) UnsafeRNG { return NewUnsafeAESCTRRNG(seed) }},
+	{"xoshiro256ssx4", 1024, false, func(seed int64) UnsafeRNG { return NewUnsafeXoshiro256ssX4RNG(seed) }},
+	{"lehmer128", 128, false, func(seed int64) UnsafeRNG { return NewUnsafeLehmer128RNG(seed) }},
+}
+
+func (c *generatorCandidate) suits(profile Profile) bool {
+	switch profile {
+	case Balanced:
+		return c.stateBits >= 256
+	case HardToPredict:
+		return c.hardToPredict
+	}
+	return true
+}
+
+const (
+	smokeCheckWords = 4096
+	benchmarkWords  = 1 << 14
+	benchmarkRounds = 3
+)
+
+// passesSmokeCheck is a quick sanity test, not a statistical test suite. It catches generators
+// that are stuck, repeat themselves, or are badly biased in their bit counts
+func passesSmokeCheck(r UnsafeRNG) bool {
+	// the count of one bits is binomial, mean 32 per word with a variance of 16 per word
+	const mean = smokeCheckWords * 32
+	const sigma = 256 // sqrt(smokeCheckWords * 16)
+	ones := 0
+	repeats := 0
+	prev := r.Uint64()
+	for i := 0; i < smokeCheckWords; i++ {
+		x := r.Uint64()
+		if x == prev {
+			repeats++
+		}
+		ones += bits.OnesCount64(x)
+		prev = x
+	}
+	return repeats == 0 && ones > mean-6*sigma && ones < mean+6*sigma
+}
+
+// benchmarkSink keeps the compiler from optimizing away the generator calls in benchmarkGenerator
+var benchmarkSink uint64
+
+// benchmarkGenerator returns the best of a few timed runs, which filters out most scheduler noise
+func benchmarkGenerator(r UnsafeRNG) time.Duration {
+	var best time.Duration
+	var sink uint64
+	for round := 0; round < benchmarkRounds; round++ {
+		start := time.Now()
+		for i := 0; i < benchmarkWords; i++ {
+			sink += r.Uint64()
+		}
+		elapsed := time.Since(start)
+		if round == 0 || elapsed < best {
+			best = elapsed
+		}
+	}
+	benchmarkSink = sink
+	return best
+}
+
+// chooseGenerator returns the fastest candidate that suits the profile and passes the smoke check,
+// falling back to xoshiro256** if nothing qualifies
+func chooseGenerator(profile Profile, seed int64) generatorCandidate {
+	chosen := generatorCandidates[1]
+	var chosenTime time.Duration
+	found := false
+	for _, c := range generatorCandidates {
+		if !c.suits(profile) {
+			continue
+		}
+		r := c.newFn(seed)
+		if !passesSmokeCheck(r) {
+			continue
+		}
+		elapsed := benchmarkGenerator(r)
+		if !found || elapsed < chosenTime {
+			chosen, chosenTime, found = c, elapsed, true
+		}
+	}
+	return chosen
+}
+
+// ChooseGenerator runs a quick micro-benchmark and quality smoke check on this machine, then returns a
+// thread safe pool backed by the generator that best fits the requested profile.
+//
+// This takes a few milliseconds, so call it once at startup and keep the result
+func ChooseGenerator(profile Profile) *ThreadsafePoolRNG {
+	c := chooseGenerator(profile, int64(freshSeed()))
+	return NewSyncPoolRNG(func() UnsafeRNG {
+		return c.newFn(int64(freshSeed()))
+	})
+}
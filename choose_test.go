@@ -0,0 +1,58 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_passesSmokeCheck(t *testing.T) {
+	for _, c := range generatorCandidates {
+		assert.True(t, passesSmokeCheck(c.newFn(1)), c.name)
+	}
+	assert.False(t, passesSmokeCheck(constRNG(0x5555555555555555)))
+	var counter UnsafeWyrandRNG
+	assert.False(t, passesSmokeCheck(&countingRNG{&counter}))
+}
+
+// countingRNG only ever produces small numbers, so its bit counts are badly skewed
+type countingRNG struct {
+	r *UnsafeWyrandRNG
+}
+
+func (c *countingRNG) Uint64() uint64 {
+	return c.r.Uint64() & 0xFF
+}
+
+func Test_chooseGenerator(t *testing.T) {
+	assert.True(t, chooseGenerator(HardToPredict, 1).hardToPredict)
+	assert.NotEqual(t, "wyrand", chooseGenerator(Balanced, 1).name)
+	assert.NotEmpty(t, chooseGenerator(MaxSpeed, 1).name)
+}
+
+func Test_ChooseGenerator(t *testing.T) {
+	for _, profile := range []Profile{MaxSpeed, Balanced, HardToPredict} {
+		rng := ChooseGenerator(profile)
+		for i := 0; i < 256; i++ {
+			assert.Less(t, rng.Uint32n(10), uint32(10))
+		}
+	}
+}
+
+func Test_UnsafeWyrandRNG_Uint64(t *testing.T) {
+	rng1 := NewUnsafeWyrandRNG(42)
+	rng2 := NewUnsafeWyrandRNG(42)
+	for i := 0; i < 256; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+	assert.NotEqual(t, NewUnsafeWyrandRNG(1).Uint64(), NewUnsafeWyrandRNG(2).Uint64())
+}
+
+func Test_NewUnsafeChaCha8RNG_Uint64(t *testing.T) {
+	rng1 := NewUnsafeChaCha8RNG(42)
+	rng2 := NewUnsafeChaCha8RNG(42)
+	for i := 0; i < 256; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+	assert.NotEqual(t, NewUnsafeChaCha8RNG(1).Uint64(), NewUnsafeChaCha8RNG(2).Uint64())
+}
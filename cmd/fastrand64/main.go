@@ -0,0 +1,126 @@
+// Command fastrand64 streams random bytes or numbers from any registered generator to stdout, for piping into
+// test batteries like PractRand or dieharder, or for making test files.
+//
+// Examples:
+//
+//	fastrand64 -gen xoshiro256ss | RNG_test stdin64
+//	fastrand64 -gen wyrand -seed 42 -n 1048576 > random.bin
+//	fastrand64 -format dec -n 10
+package main
+
+import (
+	"bufio"
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+func main() {
+	// without this the runtime kills us with SIGPIPE when the reader goes away, instead of the write failing
+	signal.Ignore(syscall.SIGPIPE)
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, "fastrand64:", err)
+		os.Exit(2)
+	}
+}
+
+func run(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("fastrand64", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	gen := flags.String("gen", "xoshiro256ss", "generator, one of "+strings.Join(fastrand64.Generators(), ", "))
+	seedFlag := flags.String("seed", "", "int64 seed, random if not set")
+	format := flags.String("format", "raw", "raw bytes, hex or dec, hex and dec print one uint64 per line")
+	count := flags.Int64("n", 0, "bytes for raw, numbers for hex and dec, 0 to stream until stdout closes")
+	block := flags.Int("block", 64*1024, "bytes per write")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+	if *count < 0 {
+		return fmt.Errorf("-n %d: must not be negative", *count)
+	}
+	if *block < 8 {
+		return fmt.Errorf("-block %d: must be at least 8", *block)
+	}
+
+	var seed int64
+	if *seedFlag != "" {
+		n, err := strconv.ParseInt(*seedFlag, 10, 64)
+		if err != nil {
+			return fmt.Errorf("-seed %q: %v", *seedFlag, err)
+		}
+		seed = n
+	} else {
+		var b [8]byte
+		cryptorand.Read(b[:])
+		seed = int64(binary.LittleEndian.Uint64(b[:]))
+	}
+	r, err := fastrand64.New(*gen, seed)
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "raw":
+		err = writeRaw(r, stdout, *count, *block)
+	case "hex", "dec":
+		err = writeNumbers(r, stdout, *format, *count, *block)
+	default:
+		return fmt.Errorf("-format %q: must be raw, hex or dec", *format)
+	}
+	if errors.Is(err, syscall.EPIPE) {
+		// the reader has had enough, which is how streaming into a test battery normally ends
+		return nil
+	}
+	return err
+}
+
+// writeRaw writes count bytes, or forever if count is 0
+func writeRaw(r fastrand64.UnsafeRNG, w io.Writer, count int64, block int) error {
+	buf := make([]byte, block)
+	for left := count; count == 0 || left > 0; {
+		p := buf
+		if count > 0 && left < int64(len(p)) {
+			p = p[:left]
+		}
+		fastrand64.Bytes(r, p)
+		if _, err := w.Write(p); err != nil {
+			return err
+		}
+		left -= int64(len(p))
+	}
+	return nil
+}
+
+// writeNumbers writes count uint64s one per line, or forever if count is 0
+func writeNumbers(r fastrand64.UnsafeRNG, w io.Writer, format string, count int64, block int) error {
+	bw := bufio.NewWriterSize(w, block)
+	line := make([]byte, 0, 24)
+	for i := int64(0); count == 0 || i < count; i++ {
+		x := r.Uint64()
+		if format == "hex" {
+			line = fmt.Appendf(line[:0], "%016x\n", x)
+		} else {
+			line = append(strconv.AppendUint(line[:0], x, 10), '\n')
+		}
+		if _, err := bw.Write(line); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+func Test_Raw(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, run([]string{"-gen", "wyrand", "-seed", "42", "-n", "1000", "-block", "64"}, &out, io.Discard))
+	assert.Equal(t, 1000, out.Len())
+
+	// the same bytes a single Bytes call gives within one block
+	want := fastrand64.Bytes(fastrand64.NewUnsafeWyrandRNG(42), make([]byte, 64))
+	assert.Equal(t, want, out.Bytes()[:64])
+}
+
+func Test_Numbers(t *testing.T) {
+	r := fastrand64.NewUnsafeXoshiro256ssRNG(7)
+	var dec, hex bytes.Buffer
+	assert.NoError(t, run([]string{"-seed", "7", "-format", "dec", "-n", "3"}, &dec, io.Discard))
+	assert.NoError(t, run([]string{"-seed", "7", "-format", "hex", "-n", "3"}, &hex, io.Discard))
+	var wantDec, wantHex string
+	for i := 0; i < 3; i++ {
+		x := r.Uint64()
+		wantDec += fmt.Sprintf("%d\n", x)
+		wantHex += fmt.Sprintf("%016x\n", x)
+	}
+	assert.Equal(t, wantDec, dec.String())
+	assert.Equal(t, wantHex, hex.String())
+}
+
+func Test_RandomSeed(t *testing.T) {
+	var a, b bytes.Buffer
+	assert.NoError(t, run([]string{"-n", "16"}, &a, io.Discard))
+	assert.NoError(t, run([]string{"-n", "16"}, &b, io.Discard))
+	assert.NotEqual(t, a.Bytes(), b.Bytes())
+}
+
+// shortWriter fails after a few writes, like a closed pipe
+type shortWriter struct{ left int }
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	if w.left == 0 {
+		return 0, io.ErrClosedPipe
+	}
+	w.left--
+	return len(p), nil
+}
+
+func Test_Errors(t *testing.T) {
+	for _, args := range [][]string{
+		{"-gen", "nope"},
+		{"-seed", "x"},
+		{"-format", "oct"},
+		{"-n", "-1"},
+		{"-block", "4"},
+		{"extra"},
+	} {
+		err := run(args, io.Discard, io.Discard)
+		assert.Error(t, err, strings.Join(args, " "))
+	}
+	// streaming forever stops at the first failed write
+	assert.Equal(t, io.ErrClosedPipe, run(nil, &shortWriter{left: 3}, io.Discard))
+}
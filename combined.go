@@ -0,0 +1,97 @@
+package fastrand64
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+)
+
+// CombineMode is how a CombinedRNG mixes its sources
+type CombineMode int
+
+const (
+	// CombineXOR xors the sources' words together, the cheapest mix. As long as the sources are independent the
+	// result is at least as unpredictable as the best of them
+	CombineXOR CombineMode = iota
+	// CombineHash chains the sources' words through splitmix64, so a weakness or a bias in one source is also
+	// scrambled by the others instead of passing straight through
+	CombineHash
+)
+
+// CryptoRNG reads every word from crypto/rand, as an UnsafeRNG. It is slow, so it is mostly useful as an
+// occasional source in a CombinedRNG. Threadsafe
+type CryptoRNG struct{}
+
+// Uint64 returns 8 bytes read from crypto/rand
+func (CryptoRNG) Uint64() uint64 {
+	var b [8]byte
+	// crypto/rand.Read never fails, it crashes the program instead
+	cryptorand.Read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// CombinedSource is one input of a CombinedRNG
+type CombinedSource struct {
+	RNG UnsafeRNG
+	// Every draws a fresh word from RNG on every Every-th call, reusing the last one in between,
+	// so an expensive source like CryptoRNG can be mixed in without paying for it on every call. 0 means 1
+	Every int
+}
+
+type combinedSource struct {
+	r     UnsafeRNG
+	every int
+	left  int // calls until the next fresh word
+	last  uint64
+}
+
+// CombinedRNG mixes the output of several generators, for defense in depth against a weak or mis-seeded source,
+// for example xoshiro256** with a crypto/rand word mixed in every few thousand calls.
+// It is unsafe to call from concurrent goroutines, unless every source is threadsafe
+type CombinedRNG struct {
+	mode    CombineMode
+	sources []combinedSource
+}
+
+// NewCombinedRNG creates a CombinedRNG mixing two or more sources with mode
+func NewCombinedRNG(mode CombineMode, sources ...CombinedSource) (*CombinedRNG, error) {
+	if mode != CombineXOR && mode != CombineHash {
+		return nil, invalidArgument("NewCombinedRNG: unknown mode %d", mode)
+	}
+	if len(sources) < 2 {
+		return nil, invalidArgument("NewCombinedRNG: need at least 2 sources, got %d", len(sources))
+	}
+	c := &CombinedRNG{mode: mode, sources: make([]combinedSource, len(sources))}
+	for i, s := range sources {
+		if s.RNG == nil {
+			return nil, invalidArgument("NewCombinedRNG: source %d is nil", i)
+		}
+		if s.Every < 0 {
+			return nil, invalidArgument("NewCombinedRNG: source %d has Every %d", i, s.Every)
+		}
+		every := s.Every
+		if every == 0 {
+			every = 1
+		}
+		c.sources[i] = combinedSource{r: s.RNG, every: every}
+	}
+	return c, nil
+}
+
+// Uint64 returns the mix of the sources' words, (not thread safe)
+func (c *CombinedRNG) Uint64() uint64 {
+	var x uint64
+	for i := range c.sources {
+		s := &c.sources[i]
+		if s.left == 0 {
+			s.last = s.r.Uint64()
+			s.left = s.every
+		}
+		s.left--
+		if c.mode == CombineHash {
+			x = Splitmix64(x ^ s.last)
+		} else {
+			x ^= s.last
+		}
+	}
+	return x
+}
@@ -0,0 +1,80 @@
+package fastrand64
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CombinedRNG_XOR(t *testing.T) {
+	a, b := NewUnsafeXoshiro256ssRNG(1), NewUnsafeWyrandRNG(2)
+	c, err := NewCombinedRNG(CombineXOR, CombinedSource{RNG: NewUnsafeXoshiro256ssRNG(1)}, CombinedSource{RNG: NewUnsafeWyrandRNG(2)})
+	assert.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, a.Uint64()^b.Uint64(), c.Uint64())
+	}
+}
+
+func Test_CombinedRNG_Every(t *testing.T) {
+	seq := NewSequenceRNG(1, 2, 3)
+	c, err := NewCombinedRNG(CombineXOR, CombinedSource{RNG: FixedRNG(0)}, CombinedSource{RNG: seq, Every: 3})
+	assert.NoError(t, err)
+	var got []uint64
+	for i := 0; i < 7; i++ {
+		got = append(got, c.Uint64())
+	}
+	assert.Equal(t, []uint64{1, 1, 1, 2, 2, 2, 3}, got)
+}
+
+func Test_CombinedRNG_Hash(t *testing.T) {
+	// xor of two equal sources cancels out, hashing doesn't
+	c, err := NewCombinedRNG(CombineHash, CombinedSource{RNG: FixedRNG(0)}, CombinedSource{RNG: FixedRNG(0)})
+	assert.NoError(t, err)
+	assert.NotEqual(t, uint64(0), c.Uint64())
+
+	// and a weak source mixed with a good one comes out uniform
+	c, _ = NewCombinedRNG(CombineHash,
+		CombinedSource{RNG: NewSequenceRNG(0, 1, 2, 3)},
+		CombinedSource{RNG: NewUnsafeXoshiro256ssRNG(1)},
+		CombinedSource{RNG: CryptoRNG{}, Every: 1000})
+	counts := make([]int, 8)
+	for i := 0; i < 80000; i++ {
+		counts[c.Uint64()>>61]++
+	}
+	for _, n := range counts {
+		assert.InDelta(t, 10000, n, 500)
+	}
+}
+
+func Test_CombinedRNG_Errors(t *testing.T) {
+	good := CombinedSource{RNG: FixedRNG(1)}
+	for _, c := range []struct {
+		mode    CombineMode
+		sources []CombinedSource
+	}{
+		{CombineXOR, []CombinedSource{good}},
+		{CombineMode(9), []CombinedSource{good, good}},
+		{CombineXOR, []CombinedSource{good, {}}},
+		{CombineHash, []CombinedSource{good, {RNG: FixedRNG(1), Every: -1}}},
+	} {
+		r, err := NewCombinedRNG(c.mode, c.sources...)
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+		assert.Nil(t, r)
+	}
+}
+
+func Test_CryptoRNG(t *testing.T) {
+	assert.NotEqual(t, CryptoRNG{}.Uint64(), CryptoRNG{}.Uint64())
+}
+
+func Benchmark_CombinedRNG_Hash_Uint64(b *testing.B) {
+	c, _ := NewCombinedRNG(CombineHash,
+		CombinedSource{RNG: NewUnsafeXoshiro256ssRNG(1)},
+		CombinedSource{RNG: CryptoRNG{}, Every: 4096})
+	var x uint64
+	for i := 0; i < b.N; i++ {
+		x += c.Uint64()
+	}
+	BenchSink = &x
+}
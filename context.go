@@ -0,0 +1,24 @@
+package fastrand64
+
+import "context"
+
+// rngKey is the context key for WithRNG
+type rngKey struct{}
+
+// WithRNG returns a copy of ctx carrying r, for FromContext to find downstream. r is usually a pool, or a
+// generator seeded from a request id so a production incident can be replayed with the same random choices.
+//
+// A thread unsafe generator must only be used by one goroutine at a time, so don't attach one to a context
+// that is shared with goroutines the request fans out to
+func WithRNG(ctx context.Context, r UnsafeRNG) context.Context {
+	return context.WithValue(ctx, rngKey{}, r)
+}
+
+// FromContext returns the generator attached to ctx by WithRNG, or a package wide pool seeded from crypto/rand
+// when there isn't one, so callers never need a nil check
+func FromContext(ctx context.Context) UnsafeRNG {
+	if r, ok := ctx.Value(rngKey{}).(UnsafeRNG); ok {
+		return r
+	}
+	return defaultRNG
+}
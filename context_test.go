@@ -0,0 +1,28 @@
+package fastrand64
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Same(t, defaultRNG, FromContext(ctx))
+
+	// a request scoped generator replays the same choices
+	seed := SeedFromString("request-1234")
+	ctx1 := WithRNG(ctx, NewUnsafeXoshiro256ssRNGFromSeed(seed))
+	ctx2 := WithRNG(ctx, NewUnsafeXoshiro256ssRNGFromSeed(seed))
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, Intn(FromContext(ctx1), 100), Intn(FromContext(ctx2), 100))
+	}
+
+	// it survives derived contexts, and the nearest one wins
+	pool := NewSyncPoolXoshiro256ssRNG()
+	child, cancel := context.WithCancel(WithRNG(ctx1, pool))
+	defer cancel()
+	assert.Same(t, pool, FromContext(child))
+	assert.NotSame(t, pool, FromContext(ctx1))
+}
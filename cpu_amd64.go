@@ -0,0 +1,30 @@
+//go:build amd64 && !purego
+
+package fastrand64
+
+// cpuid executes the CPUID instruction for leaf eaxArg, subleaf ecxArg
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+// xgetbv reads XCR0, which says which register sets the OS saves on a context switch
+func xgetbv() (eax, edx uint32)
+
+var hasAVX2 = detectAVX2()
+
+func detectAVX2() bool {
+	maxLeaf, _, _, _ := cpuid(0, 0)
+	if maxLeaf < 7 {
+		return false
+	}
+	_, _, ecx1, _ := cpuid(1, 0)
+	const osxsave = 1 << 27
+	const avx = 1 << 28
+	if ecx1&osxsave == 0 || ecx1&avx == 0 {
+		return false
+	}
+	// the OS has to save the XMM and YMM registers, or they get trashed by the next context switch
+	if xcr0, _ := xgetbv(); xcr0&6 != 6 {
+		return false
+	}
+	_, ebx7, _, _ := cpuid(7, 0)
+	return ebx7&(1<<5) != 0
+}
@@ -0,0 +1,108 @@
+// Package dist implements samplers for common continuous and multivariate distributions, and stratified and
+// quasi-random sampling for Monte Carlo, on top of fastrand64.
+//
+// Every sampler is a plain function taking any fastrand64.UnsafeRNG as its source, which is not safe to
+// share between goroutines. Wrap a ThreadsafePoolRNG in a Sampler to get thread safe versions that check
+// a generator out of the pool once per value.
+//
+// Example:
+//
+//	rng := fastrand64.NewSyncPoolXoshiro256ssRNG()
+//	s := dist.NewSampler(rng)
+//
+//	// somewhere later, in some goproc
+//	serviceTime := s.Gamma(2, 0.5)
+//	fileSize := s.Pareto(1024, 1.2)
+package dist
+
+import (
+	"math"
+
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+// Gamma returns a gamma distributed float64 with the given shape (k) and scale (theta), mean shape*scale
+//
+// Uses Marsaglia & Tsang, "A Simple Method for Generating Gamma Variables" (2000).
+// It panics if shape or scale is not positive and finite
+func Gamma(r fastrand64.UnsafeRNG, shape, scale float64) float64 {
+	if !(shape > 0) || !(scale > 0) || math.IsInf(shape, 0) || math.IsInf(scale, 0) {
+		panic("invalid argument to Gamma")
+	}
+	return gamma(r, shape) * scale
+}
+
+// gamma samples Gamma(shape, 1) for a shape already known to be valid
+func gamma(r fastrand64.UnsafeRNG, shape float64) float64 {
+	if shape < 1 {
+		// boost the shape above 1, then scale back down with U^(1/shape)
+		return gamma(r, shape+1) * math.Pow(fastrand64.Float64OpenClosed(r), 1/shape)
+	}
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := fastrand64.NormFloat64(r)
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := fastrand64.Float64OpenClosed(r)
+		x2 := x * x
+		if u < 1-0.0331*x2*x2 {
+			return d * v
+		}
+		if math.Log(u) < 0.5*x2+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+// Beta returns a beta distributed float64 in [0, 1] with shape parameters alpha and beta
+//
+// It panics if alpha or beta is not positive and finite
+func Beta(r fastrand64.UnsafeRNG, alpha, beta float64) float64 {
+	if !(alpha > 0) || !(beta > 0) || math.IsInf(alpha, 0) || math.IsInf(beta, 0) {
+		panic("invalid argument to Beta")
+	}
+	x := gamma(r, alpha)
+	y := gamma(r, beta)
+	if x+y == 0 {
+		// both underflowed, which only happens for tiny shapes where the mass is piled up at 0 and 1
+		if fastrand64.Float64(r) < alpha/(alpha+beta) {
+			return 1
+		}
+		return 0
+	}
+	return x / (x + y)
+}
+
+// LogNormal returns a float64 whose logarithm is normally distributed with mean mu and standard deviation sigma
+//
+// It panics if sigma is negative
+func LogNormal(r fastrand64.UnsafeRNG, mu, sigma float64) float64 {
+	if !(sigma >= 0) {
+		panic("invalid argument to LogNormal")
+	}
+	return math.Exp(mu + sigma*fastrand64.NormFloat64(r))
+}
+
+// Pareto returns a pareto (type I) distributed float64 with minimum xm and tail index alpha
+//
+// It panics if xm or alpha is not positive
+func Pareto(r fastrand64.UnsafeRNG, xm, alpha float64) float64 {
+	if !(xm > 0) || !(alpha > 0) {
+		panic("invalid argument to Pareto")
+	}
+	return xm / math.Pow(fastrand64.Float64OpenClosed(r), 1/alpha)
+}
+
+// Weibull returns a weibull distributed float64 with the given scale (lambda) and shape (k)
+//
+// It panics if scale or shape is not positive
+func Weibull(r fastrand64.UnsafeRNG, scale, shape float64) float64 {
+	if !(scale > 0) || !(shape > 0) {
+		panic("invalid argument to Weibull")
+	}
+	return scale * math.Pow(-math.Log(fastrand64.Float64OpenClosed(r)), 1/shape)
+}
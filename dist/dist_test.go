@@ -0,0 +1,81 @@
+package dist
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+// sampleMoments returns the mean and variance of n samples from fn
+func sampleMoments(n int, fn func() float64) (float64, float64) {
+	sum := 0.0
+	sumSq := 0.0
+	for i := 0; i < n; i++ {
+		x := fn()
+		sum += x
+		sumSq += x * x
+	}
+	mean := sum / float64(n)
+	return mean, sumSq/float64(n) - mean*mean
+}
+
+func Test_Gamma(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	for _, shape := range []float64{0.3, 1, 2.5, 50} {
+		mean, variance := sampleMoments(200000, func() float64 { return Gamma(rng, shape, 2) })
+		assert.InDelta(t, shape*2, mean, 0.02*shape*2, "shape=%v", shape)
+		assert.InDelta(t, shape*4, variance, 0.05*shape*4, "shape=%v", shape)
+	}
+	assert.Panics(t, func() { Gamma(rng, 0, 1) })
+	assert.Panics(t, func() { Gamma(rng, 1, -1) })
+}
+
+func Test_Beta(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	mean, _ := sampleMoments(200000, func() float64 {
+		x := Beta(rng, 2, 5)
+		assert.True(t, x >= 0 && x <= 1)
+		return x
+	})
+	assert.InDelta(t, 2.0/7, mean, 0.005)
+	assert.Panics(t, func() { Beta(rng, 0, 1) })
+}
+
+func Test_LogNormal(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	mean, _ := sampleMoments(200000, func() float64 { return LogNormal(rng, 1, 0.5) })
+	assert.InDelta(t, math.Exp(1+0.125), mean, 0.03)
+	assert.Panics(t, func() { LogNormal(rng, 0, -1) })
+}
+
+func Test_Pareto(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	mean, _ := sampleMoments(200000, func() float64 {
+		x := Pareto(rng, 2, 3)
+		assert.GreaterOrEqual(t, x, 2.0)
+		return x
+	})
+	assert.InDelta(t, 3.0, mean, 0.05)
+	assert.Panics(t, func() { Pareto(rng, 0, 1) })
+}
+
+func Test_Weibull(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	mean, _ := sampleMoments(200000, func() float64 { return Weibull(rng, 2, 1.5) })
+	g := math.Gamma(1 + 1/1.5)
+	assert.InDelta(t, 2*g, mean, 0.02)
+	assert.Panics(t, func() { Weibull(rng, 1, 0) })
+}
+
+func Test_Sampler(t *testing.T) {
+	s := NewSampler(fastrand64.NewSyncPoolXoshiro256ssRNG())
+	mean, _ := sampleMoments(50000, func() float64 { return s.Gamma(3, 1) })
+	assert.InDelta(t, 3.0, mean, 0.1)
+	x := s.Beta(1, 1)
+	assert.True(t, x >= 0 && x <= 1)
+	assert.Greater(t, s.LogNormal(0, 1), 0.0)
+	assert.GreaterOrEqual(t, s.Pareto(1, 2), 1.0)
+	assert.GreaterOrEqual(t, s.Weibull(1, 2), 0.0)
+}
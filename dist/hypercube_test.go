@@ -0,0 +1,54 @@
+package dist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+func Test_LatinHypercube(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	const n, dims = 50, 4
+	points := LatinHypercube(rng, n, dims)
+	assert.Len(t, points, n)
+	for d := 0; d < dims; d++ {
+		// every interval of every dimension holds exactly one point
+		seen := make([]bool, n)
+		for _, p := range points {
+			assert.Len(t, p, dims)
+			assert.True(t, p[d] >= 0 && p[d] < 1)
+			k := int(p[d] * n)
+			assert.False(t, seen[k], "dimension %d interval %d", d, k)
+			seen[k] = true
+		}
+	}
+	// the dimensions are shuffled independently
+	same := 0
+	for _, p := range points {
+		if int(p[0]*n) == int(p[1]*n) {
+			same++
+		}
+	}
+	assert.Less(t, same, 5)
+
+	// appending to one point doesn't clobber the next
+	points[0] = append(points[0], 9)
+	assert.NotEqual(t, 9.0, points[1][0])
+
+	assert.Empty(t, LatinHypercube(rng, 0, 3))
+	assert.Equal(t, [][]float64{{}, {}}, LatinHypercube(rng, 2, 0))
+	assert.Panics(t, func() { LatinHypercube(rng, -1, 1) })
+	assert.Panics(t, func() { LatinHypercube(rng, 1, -1) })
+}
+
+func Test_StratumPoint(t *testing.T) {
+	// (2 + (1 - 2^-53)) rounds to 3, and 3/3 would leave [0, 1)
+	assert.True(t, stratumPoint(2, 3, 1-0x1p-53) < 1)
+	assert.Equal(t, 0.5, stratumPoint(1, 2, 0))
+}
+
+func Test_Sampler_LatinHypercube(t *testing.T) {
+	s := NewSampler(fastrand64.NewSyncPoolXoshiro256ssRNG())
+	assert.Len(t, s.LatinHypercube(10, 3), 10)
+}
@@ -0,0 +1,83 @@
+package dist
+
+import (
+	"math"
+
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+// Dirichlet returns a Dirichlet distributed point, len(alpha) non-negative float64s summing to 1, the
+// conjugate prior of the categorical distribution. Component i has mean alpha[i]/sum(alpha).
+//
+// Each component is a Gamma(alpha[i]) deviate divided by their sum. It panics if alpha is empty, or any alpha
+// is not positive and finite
+func Dirichlet(r fastrand64.UnsafeRNG, alpha []float64) []float64 {
+	if len(alpha) == 0 {
+		panic("invalid argument to Dirichlet")
+	}
+	total := 0.0
+	for _, a := range alpha {
+		if !(a > 0) || math.IsInf(a, 0) {
+			panic("invalid argument to Dirichlet")
+		}
+		total += a
+	}
+	x := make([]float64, len(alpha))
+	sum := 0.0
+	for i, a := range alpha {
+		x[i] = gamma(r, a)
+		sum += x[i]
+	}
+	if sum == 0 {
+		// every component underflowed, which only happens for tiny alphas where the mass is piled up at the
+		// corners, so pick a corner with the same odds
+		u := fastrand64.Float64(r) * total
+		i := 0
+		for ; i < len(alpha)-1 && u >= alpha[i]; i++ {
+			u -= alpha[i]
+		}
+		x[i] = 1
+		return x
+	}
+	for i := range x {
+		x[i] /= sum
+	}
+	return x
+}
+
+// Multinomial returns how many of n trials land in each category, when each trial picks category i with
+// probability probs[i]. probs don't need to sum to 1, they are normalized.
+//
+// It draws one binomial per category, conditioned on the trials left, so the cost doesn't grow with n.
+// It panics if n < 0, probs is empty, or any prob is negative or not finite or they are all 0
+func Multinomial(r fastrand64.UnsafeRNG, n int, probs []float64) []int {
+	if n < 0 || len(probs) == 0 {
+		panic("invalid argument to Multinomial")
+	}
+	total := 0.0
+	for _, p := range probs {
+		if !(p >= 0) || math.IsInf(p, 0) {
+			panic("invalid argument to Multinomial")
+		}
+		total += p
+	}
+	if !(total > 0) || math.IsInf(total, 0) {
+		panic("invalid argument to Multinomial")
+	}
+	counts := make([]int, len(probs))
+	left := n
+	for i, p := range probs {
+		if left == 0 {
+			break
+		}
+		if i == len(probs)-1 || p >= total {
+			counts[i] = left
+			break
+		}
+		k := fastrand64.Binomial(r, left, p/total)
+		counts[i] = k
+		left -= k
+		total -= p
+	}
+	return counts
+}
@@ -0,0 +1,82 @@
+package dist
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+func Test_Dirichlet(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	alpha := []float64{1, 2, 7}
+	means := make([]float64, 3)
+	const n = 100000
+	for i := 0; i < n; i++ {
+		x := Dirichlet(rng, alpha)
+		sum := 0.0
+		for j, v := range x {
+			assert.True(t, v >= 0 && v <= 1)
+			sum += v
+			means[j] += v / n
+		}
+		assert.InDelta(t, 1, sum, 1e-12)
+	}
+	for j, a := range alpha {
+		assert.InDelta(t, a/10, means[j], 0.005)
+	}
+
+	// tiny alphas put nearly all the mass on one corner, picked in proportion to alpha
+	corners := make([]int, 2)
+	for i := 0; i < 10000; i++ {
+		x := Dirichlet(rng, []float64{1e-300, 3e-300})
+		assert.InDelta(t, 1, x[0]+x[1], 1e-12)
+		if x[0] > x[1] {
+			corners[0]++
+		} else {
+			corners[1]++
+		}
+	}
+	assert.InDelta(t, 2500, corners[0], 6*math.Sqrt(1875))
+
+	assert.Panics(t, func() { Dirichlet(rng, nil) })
+	assert.Panics(t, func() { Dirichlet(rng, []float64{1, 0}) })
+	assert.Panics(t, func() { Dirichlet(rng, []float64{1, math.Inf(1)}) })
+}
+
+func Test_Multinomial(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	probs := []float64{2, 0, 1, 1} // normalized to 0.5, 0, 0.25, 0.25
+	means := make([]float64, 4)
+	const trials = 20000
+	for i := 0; i < trials; i++ {
+		counts := Multinomial(rng, 100, probs)
+		total := 0
+		for j, c := range counts {
+			total += c
+			means[j] += float64(c) / trials
+		}
+		assert.Equal(t, 100, total)
+		assert.Equal(t, 0, counts[1])
+	}
+	assert.InDelta(t, 50, means[0], 0.2)
+	assert.InDelta(t, 25, means[2], 0.2)
+	assert.InDelta(t, 25, means[3], 0.2)
+
+	// huge n costs no more than small n
+	counts := Multinomial(rng, math.MaxInt32, []float64{1, 1})
+	assert.InDelta(t, 0.5, float64(counts[0])/math.MaxInt32, 1e-3)
+
+	assert.Equal(t, []int{0, 0}, Multinomial(rng, 0, []float64{1, 1}))
+	assert.Panics(t, func() { Multinomial(rng, -1, []float64{1}) })
+	assert.Panics(t, func() { Multinomial(rng, 1, nil) })
+	assert.Panics(t, func() { Multinomial(rng, 1, []float64{0, 0}) })
+	assert.Panics(t, func() { Multinomial(rng, 1, []float64{1, -1}) })
+}
+
+func Test_Sampler_Multivariate(t *testing.T) {
+	s := NewSampler(fastrand64.NewSyncPoolXoshiro256ssRNG())
+	assert.Equal(t, 3, len(s.Dirichlet([]float64{1, 1, 1})))
+	assert.Equal(t, 2, len(s.Multinomial(10, []float64{1, 1})))
+}
@@ -0,0 +1,76 @@
+package dist
+
+import fastrand64 "github.com/villenny/fastrand64-go"
+
+// Sampler provides thread safe versions of the samplers in this package, each value checks a generator out
+// of the pool once no matter how many draws the sampler needs
+type Sampler struct {
+	rng *fastrand64.ThreadsafePoolRNG
+}
+
+// NewSampler wraps a thread safe pool backed RNG
+func NewSampler(rng *fastrand64.ThreadsafePoolRNG) *Sampler {
+	return &Sampler{rng: rng}
+}
+
+// Gamma returns a gamma distributed float64 with the given shape and scale. Threadsafe
+func (s *Sampler) Gamma(shape, scale float64) float64 {
+	var x float64
+	s.rng.With(func(r fastrand64.UnsafeRNG) { x = Gamma(r, shape, scale) })
+	return x
+}
+
+// Beta returns a beta distributed float64 with shape parameters alpha and beta. Threadsafe
+func (s *Sampler) Beta(alpha, beta float64) float64 {
+	var x float64
+	s.rng.With(func(r fastrand64.UnsafeRNG) { x = Beta(r, alpha, beta) })
+	return x
+}
+
+// LogNormal returns a log normally distributed float64 with parameters mu and sigma. Threadsafe
+func (s *Sampler) LogNormal(mu, sigma float64) float64 {
+	var x float64
+	s.rng.With(func(r fastrand64.UnsafeRNG) { x = LogNormal(r, mu, sigma) })
+	return x
+}
+
+// Pareto returns a pareto distributed float64 with minimum xm and tail index alpha. Threadsafe
+func (s *Sampler) Pareto(xm, alpha float64) float64 {
+	var x float64
+	s.rng.With(func(r fastrand64.UnsafeRNG) { x = Pareto(r, xm, alpha) })
+	return x
+}
+
+// Weibull returns a weibull distributed float64 with the given scale and shape. Threadsafe
+func (s *Sampler) Weibull(scale, shape float64) float64 {
+	var x float64
+	s.rng.With(func(r fastrand64.UnsafeRNG) { x = Weibull(r, scale, shape) })
+	return x
+}
+
+// Dirichlet returns a Dirichlet distributed point with concentration parameters alpha. Threadsafe
+func (s *Sampler) Dirichlet(alpha []float64) []float64 {
+	var x []float64
+	s.rng.With(func(r fastrand64.UnsafeRNG) { x = Dirichlet(r, alpha) })
+	return x
+}
+
+// Multinomial returns how many of n trials land in each category. Threadsafe
+func (s *Sampler) Multinomial(n int, probs []float64) []int {
+	var x []int
+	s.rng.With(func(r fastrand64.UnsafeRNG) { x = Multinomial(r, n, probs) })
+	return x
+}
+
+// LatinHypercube returns n stratified points in the unit cube [0, 1)^dims. Threadsafe
+func (s *Sampler) LatinHypercube(n, dims int) [][]float64 {
+	var x [][]float64
+	s.rng.With(func(r fastrand64.UnsafeRNG) { x = LatinHypercube(r, n, dims) })
+	return x
+}
+
+// ScrambledSobol creates a Sobol sequence in dims dimensions scrambled with randomness from the pool, see
+// NewScrambledSobol. The sequence itself is not threadsafe
+func (s *Sampler) ScrambledSobol(dims int) *Sobol {
+	return NewScrambledSobol(s.rng, dims)
+}
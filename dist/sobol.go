@@ -0,0 +1,164 @@
+package dist
+
+import (
+	"math/bits"
+
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+// SobolMaxDims is the most dimensions NewSobol supports
+const SobolMaxDims = 64
+
+// sobolBits is the precision of the direction numbers, which also caps a sequence at 2^32 points
+const sobolBits = 32
+
+// sobolPolys are the primitive polynomials over GF(2) for dimensions 2 and up, in order of degree then value.
+// Bit i is the coefficient of x^i
+var sobolPolys = [SobolMaxDims - 1]uint16{
+	0x3, 0x7, 0xb, 0xd, 0x13, 0x19, 0x25, 0x29, 0x2f, 0x37, 0x3b, 0x3d, 0x43, 0x5b, 0x61, 0x67,
+	0x6d, 0x73, 0x83, 0x89, 0x8f, 0x91, 0x9d, 0xa7, 0xab, 0xb9, 0xbf, 0xc1, 0xcb, 0xd3, 0xd5, 0xe5,
+	0xef, 0xf1, 0xf7, 0xfd, 0x11d, 0x12b, 0x12d, 0x14d, 0x15f, 0x163, 0x165, 0x169, 0x171, 0x187, 0x18d, 0x1a9,
+	0x1c3, 0x1cf, 0x1e7, 0x1f5, 0x211, 0x21b, 0x221, 0x22d, 0x233, 0x259, 0x25f, 0x269, 0x26f, 0x277, 0x27d,
+}
+
+// sobolInit are the initial direction numbers m_1 ... m_deg for each polynomial in sobolPolys, from Joe and
+// Kuo's new-joe-kuo-6.21201 table (https://web.maths.unsw.edu.au/~fkuo/sobol/), chosen to give good two
+// dimensional projections
+var sobolInit = [SobolMaxDims - 1][]uint32{
+	{1}, {1, 3}, {1, 3, 1}, {1, 1, 1}, {1, 1, 3, 3}, {1, 3, 5, 13}, {1, 1, 5, 5, 17}, {1, 1, 5, 5, 5},
+	{1, 1, 7, 11, 19}, {1, 1, 5, 1, 1}, {1, 1, 1, 3, 11}, {1, 3, 5, 5, 31}, {1, 3, 3, 9, 7, 49},
+	{1, 1, 1, 15, 21, 21}, {1, 3, 1, 13, 27, 49}, {1, 1, 1, 15, 7, 5}, {1, 3, 1, 15, 13, 25},
+	{1, 1, 5, 5, 19, 61}, {1, 3, 7, 11, 23, 15, 103}, {1, 3, 7, 13, 13, 15, 69}, {1, 1, 3, 13, 7, 35, 63},
+	{1, 3, 5, 9, 1, 25, 53}, {1, 3, 1, 13, 9, 35, 107}, {1, 3, 1, 5, 27, 61, 31}, {1, 1, 5, 11, 19, 41, 61},
+	{1, 3, 5, 3, 3, 13, 69}, {1, 1, 7, 13, 1, 19, 1}, {1, 3, 7, 5, 13, 19, 59}, {1, 1, 3, 9, 25, 29, 41},
+	{1, 3, 5, 13, 23, 1, 55}, {1, 3, 7, 3, 13, 59, 17}, {1, 3, 1, 3, 5, 53, 69}, {1, 1, 5, 5, 23, 33, 13},
+	{1, 1, 7, 7, 1, 61, 123}, {1, 1, 7, 9, 13, 61, 49}, {1, 3, 3, 5, 3, 55, 33}, {1, 3, 1, 15, 31, 13, 49, 245},
+	{1, 3, 5, 15, 31, 59, 63, 97}, {1, 3, 1, 11, 11, 11, 77, 249}, {1, 3, 1, 11, 27, 43, 71, 9},
+	{1, 1, 7, 15, 21, 11, 81, 45}, {1, 3, 7, 3, 25, 31, 65, 79}, {1, 3, 1, 1, 19, 11, 3, 205},
+	{1, 1, 5, 9, 19, 21, 29, 157}, {1, 3, 7, 11, 1, 33, 89, 185}, {1, 3, 3, 3, 15, 9, 79, 71},
+	{1, 3, 7, 11, 15, 39, 119, 27}, {1, 1, 3, 1, 11, 31, 97, 225}, {1, 1, 1, 3, 23, 43, 57, 177},
+	{1, 3, 7, 7, 17, 17, 37, 71}, {1, 3, 1, 5, 27, 63, 123, 213}, {1, 1, 3, 5, 11, 43, 53, 133},
+	{1, 3, 5, 5, 29, 17, 47, 173, 479}, {1, 3, 3, 11, 3, 1, 109, 9, 69}, {1, 1, 1, 5, 17, 39, 23, 5, 343},
+	{1, 3, 1, 5, 25, 15, 31, 103, 499}, {1, 1, 1, 11, 11, 17, 63, 105, 183}, {1, 1, 5, 11, 9, 29, 97, 231, 363},
+	{1, 1, 5, 15, 19, 45, 41, 7, 383}, {1, 3, 7, 7, 31, 19, 83, 137, 221}, {1, 1, 1, 3, 23, 15, 111, 223, 83},
+	{1, 1, 5, 13, 31, 15, 55, 25, 161}, {1, 1, 3, 13, 25, 47, 39, 87, 257},
+}
+
+// Sobol generates the Sobol low discrepancy sequence, points in [0, 1)^dims that fill the cube far more
+// evenly than independent uniform points, for quasi-Monte Carlo integration. Like a generator it is not safe
+// to share between goroutines.
+//
+// The first dimension is the van der Corput sequence, the rest use primitive polynomials in the usual order
+// with Joe and Kuo's direction numbers, so unscrambled points match other libraries using the same table.
+// Points come in Gray code order, and any 2^m points from index 0 put one point in each interval of width
+// 2^-m in every dimension
+type Sobol struct {
+	dirs  [][sobolBits]uint32 // per dimension, direction number k has its top k+1 bits significant
+	x     []uint32
+	shift []uint32
+	n     uint64 // points returned so far
+}
+
+// NewSobol creates the Sobol sequence in dims dimensions, starting from the point at the origin.
+// It panics if dims is not in [1, SobolMaxDims]
+func NewSobol(dims int) *Sobol {
+	if dims < 1 || dims > SobolMaxDims {
+		panic("invalid argument to NewSobol")
+	}
+	s := &Sobol{dirs: make([][sobolBits]uint32, dims), x: make([]uint32, dims), shift: make([]uint32, dims)}
+	for k := range s.dirs[0] {
+		s.dirs[0][k] = 1 << (sobolBits - 1 - k)
+	}
+	for d := 1; d < dims; d++ {
+		s.dirs[d] = sobolDirections(sobolPolys[d-1], sobolInit[d-1])
+	}
+	return s
+}
+
+// sobolDirections returns the direction numbers for the primitive polynomial poly, starting from init
+func sobolDirections(poly uint16, init []uint32) [sobolBits]uint32 {
+	deg := bits.Len16(poly) - 1
+	var m [sobolBits]uint32
+	copy(m[:], init)
+	for k := deg; k < sobolBits; k++ {
+		x := m[k-deg] ^ m[k-deg]<<deg
+		for j := 1; j < deg; j++ {
+			if poly>>(deg-j)&1 == 1 {
+				x ^= m[k-j] << j
+			}
+		}
+		m[k] = x
+	}
+	var v [sobolBits]uint32
+	for k := range v {
+		v[k] = m[k] << (sobolBits - 1 - k)
+	}
+	return v
+}
+
+// NewScrambledSobol creates a Sobol sequence randomized with a random linear scramble and a random digital
+// shift drawn from r, a pool is fine. The points keep their stratification, but no longer start at the
+// origin, and averaging estimates over several independently scrambled sequences gives an error estimate.
+// It panics if dims is not in [1, SobolMaxDims]
+func NewScrambledSobol(r fastrand64.UnsafeRNG, dims int) *Sobol {
+	s := NewSobol(dims)
+	for d := range s.dirs {
+		// row i of a random lower triangular matrix with a unit diagonal, as a mask over the bits of a
+		// direction number, most significant first
+		var rows [sobolBits]uint32
+		for i := range rows {
+			rows[i] = uint32(r.Uint64())&^(1<<(sobolBits-1-i)-1) | 1<<(sobolBits-1-i)
+		}
+		for k, v := range s.dirs[d] {
+			var scrambled uint32
+			for i, row := range rows {
+				scrambled |= uint32(bits.OnesCount32(row&v)&1) << (sobolBits - 1 - i)
+			}
+			s.dirs[d][k] = scrambled
+		}
+		s.shift[d] = uint32(r.Uint64())
+		s.x[d] = s.shift[d]
+	}
+	return s
+}
+
+// Dims returns the number of dimensions
+func (s *Sobol) Dims() int {
+	return len(s.x)
+}
+
+// Index returns how many points have been returned, the index of the next one
+func (s *Sobol) Index() uint64 {
+	return s.n
+}
+
+// Next stores the next point in dst and returns it, dst is allocated if it is nil. It panics if dst has the
+// wrong length, or once all 2^32 points have been used
+func (s *Sobol) Next(dst []float64) []float64 {
+	if dst == nil {
+		dst = make([]float64, len(s.x))
+	}
+	if len(dst) != len(s.x) {
+		panic("invalid argument to Sobol.Next")
+	}
+	if s.n >= 1<<sobolBits {
+		panic("dist: Sobol sequence exhausted")
+	}
+	for d, x := range s.x {
+		dst[d] = float64(x) / (1 << sobolBits)
+	}
+	// Gray code order: each step flips the direction number of the lowest zero bit of the old index
+	s.n++
+	if c := bits.TrailingZeros64(s.n); c < sobolBits {
+		for d := range s.x {
+			s.x[d] ^= s.dirs[d][c]
+		}
+	}
+	return dst
+}
+
+// Reset restarts the sequence from its first point, keeping any scrambling
+func (s *Sobol) Reset() {
+	copy(s.x, s.shift)
+	s.n = 0
+}
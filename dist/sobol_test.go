@@ -0,0 +1,147 @@
+package dist
+
+import (
+	"math"
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+// stratified reports whether the first 2^m points put one point in each interval of width 2^-m in every
+// dimension
+func stratified(s *Sobol, m int) bool {
+	n := 1 << m
+	seen := make([][]bool, s.Dims())
+	for d := range seen {
+		seen[d] = make([]bool, n)
+	}
+	p := make([]float64, s.Dims())
+	for i := 0; i < n; i++ {
+		s.Next(p)
+		for d, x := range p {
+			k := int(x * float64(n))
+			if x < 0 || x >= 1 || seen[d][k] {
+				return false
+			}
+			seen[d][k] = true
+		}
+	}
+	return true
+}
+
+func Test_sobolPolys(t *testing.T) {
+	// every polynomial is primitive: x has order 2^deg-1 modulo it
+	for _, p := range sobolPolys {
+		deg := 0
+		for p>>(deg+1) != 0 {
+			deg++
+		}
+		order := 0
+		x := uint32(1)
+		for {
+			x <<= 1
+			if x>>deg&1 == 1 {
+				x ^= uint32(p)
+			}
+			order++
+			if x == 1 {
+				break
+			}
+		}
+		assert.Equal(t, 1<<deg-1, order, "%#x", p)
+	}
+}
+
+func Test_sobolInit(t *testing.T) {
+	// one odd m_k < 2^k per degree of the polynomial
+	for d, init := range sobolInit {
+		assert.Equal(t, bits.Len16(sobolPolys[d])-1, len(init), "dimension %d", d+2)
+		for k, m := range init {
+			assert.True(t, m&1 == 1 && m < 1<<(k+1), "dimension %d m_%d = %d", d+2, k+1, m)
+		}
+	}
+}
+
+func Test_Sobol_Reference(t *testing.T) {
+	// the first two columns are the points scipy.stats.qmc.Sobol(d=2, scramble=False) documents, it uses the
+	// same table and Gray code order. The third follows by hand from x^2 + x + 1 and m = 1, 3
+	expected := [][]float64{
+		{0, 0, 0},
+		{0.5, 0.5, 0.5},
+		{0.75, 0.25, 0.25},
+		{0.25, 0.75, 0.75},
+		{0.375, 0.375, 0.625},
+		{0.875, 0.875, 0.125},
+		{0.625, 0.125, 0.875},
+		{0.125, 0.625, 0.375},
+	}
+	s := NewSobol(3)
+	for _, p := range expected {
+		assert.Equal(t, p, s.Next(nil))
+	}
+}
+
+func Test_Sobol(t *testing.T) {
+	s := NewSobol(3)
+	assert.Equal(t, []float64{0, 0, 0}, s.Next(nil))
+	assert.Equal(t, []float64{0.5, 0.5, 0.5}, s.Next(nil))
+	assert.Equal(t, 0.75, s.Next(nil)[0])
+	assert.Equal(t, 0.25, s.Next(nil)[0])
+	assert.Equal(t, uint64(4), s.Index())
+
+	s.Reset()
+	assert.Equal(t, []float64{0, 0, 0}, s.Next(nil))
+
+	for _, dims := range []int{1, 2, 10, SobolMaxDims} {
+		assert.True(t, stratified(NewSobol(dims), 10), "dims=%d", dims)
+	}
+
+	assert.Panics(t, func() { NewSobol(0) })
+	assert.Panics(t, func() { NewSobol(SobolMaxDims + 1) })
+	assert.Panics(t, func() { NewSobol(2).Next(make([]float64, 3)) })
+	s.n = 1 << 32
+	assert.Panics(t, func() { s.Next(nil) })
+}
+
+func Test_ScrambledSobol(t *testing.T) {
+	rng := fastrand64.NewSyncPoolXoshiro256ssRNG()
+	a := NewScrambledSobol(rng, 8)
+	b := NewScrambledSobol(rng, 8)
+	first := a.Next(nil)
+	assert.NotEqual(t, first, b.Next(nil))
+	assert.NotEqual(t, make([]float64, 8), first)
+	a.Reset()
+	assert.Equal(t, first, a.Next(nil))
+
+	a.Reset()
+	assert.True(t, stratified(a, 10))
+	assert.True(t, stratified(NewScrambledSobol(fastrand64.NewUnsafeXoshiro256ssRNG(1), SobolMaxDims), 8))
+	assert.True(t, stratified(NewSampler(rng).ScrambledSobol(4), 8))
+}
+
+func Test_Sobol_Integrates(t *testing.T) {
+	// the mean of prod(2x) over the unit cube is 1, quasi-Monte Carlo gets much closer than Monte Carlo
+	const dims, n = 5, 1 << 12
+	f := func(p []float64) float64 {
+		y := 1.0
+		for _, x := range p {
+			y *= 2 * x
+		}
+		return y
+	}
+	s := NewScrambledSobol(fastrand64.NewUnsafeXoshiro256ssRNG(1), dims)
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	p := make([]float64, dims)
+	qmc, mc := 0.0, 0.0
+	for i := 0; i < n; i++ {
+		qmc += f(s.Next(p)) / n
+		for d := range p {
+			p[d] = fastrand64.Float64(rng)
+		}
+		mc += f(p) / n
+	}
+	assert.InDelta(t, 1, qmc, 0.02)
+	assert.Less(t, math.Abs(qmc-1), math.Abs(mc-1))
+}
@@ -0,0 +1,64 @@
+package fastrand64
+
+import (
+	"math"
+	"time"
+)
+
+// DurationRange returns a pseudorandom time.Duration in the range [min, max) from a thread unsafe RNG,
+// every nanosecond equally likely. Handy for jittered TTLs and schedules.
+//
+// It panics if min >= max
+func DurationRange(r UnsafeRNG, min, max time.Duration) time.Duration {
+	if min >= max {
+		panic("invalid argument to DurationRange")
+	}
+	// the span can be more than MaxInt64 but always fits a uint64
+	return min + time.Duration(Uint64n(r, uint64(max)-uint64(min)))
+}
+
+// TimeRange returns a pseudorandom time.Time in the range [from, to) from a thread unsafe RNG, every nanosecond
+// equally likely, in from's location. Spans of any length work, not just the 292 years a time.Duration covers.
+//
+// It panics if from is not before to
+func TimeRange(r UnsafeRNG, from, to time.Time) time.Time {
+	if !from.Before(to) {
+		panic("invalid argument to TimeRange")
+	}
+	secs := to.Unix() - from.Unix()
+	nanos := int64(to.Nanosecond()) - int64(from.Nanosecond())
+	if secs < math.MaxInt64/int64(time.Second)-1 {
+		span := uint64(secs)*1e9 + uint64(nanos)
+		return from.Add(time.Duration(Uint64n(r, span)))
+	}
+	// too long to count in nanoseconds, so pick a second and a nanosecond within it, and draw again if that
+	// lands past the end
+	for {
+		sec := int64(Uint64n(r, uint64(secs)+1))
+		ns := int64(Uint64n(r, 1e9))
+		// sec*1e9 + ns < secs*1e9 + nanos, without the overflow
+		if d := secs - sec; d > 1 || d*1e9 > ns-nanos {
+			return time.Unix(from.Unix()+sec, int64(from.Nanosecond())+ns).In(from.Location())
+		}
+	}
+}
+
+// DurationRange returns a pseudorandom time.Duration in the range [min, max). Threadsafe
+//
+// It panics if min >= max
+func (s *ThreadsafePoolRNG) DurationRange(min, max time.Duration) time.Duration {
+	r, pool := s.get()
+	x := DurationRange(r, min, max)
+	pool.put(r)
+	return x
+}
+
+// TimeRange returns a pseudorandom time.Time in the range [from, to), in from's location. Threadsafe
+//
+// It panics if from is not before to
+func (s *ThreadsafePoolRNG) TimeRange(from, to time.Time) time.Time {
+	r, pool := s.get()
+	x := TimeRange(r, from, to)
+	pool.put(r)
+	return x
+}
@@ -0,0 +1,70 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DurationRange(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	counts := make([]int, 4)
+	for i := 0; i < 40000; i++ {
+		d := DurationRange(rng, 10*time.Second, 14*time.Second)
+		assert.True(t, d >= 10*time.Second && d < 14*time.Second)
+		counts[(d-10*time.Second)/time.Second]++
+	}
+	for _, n := range counts {
+		assert.InDelta(t, 10000, n, 6*math.Sqrt(10000))
+	}
+	// the full range doesn't overflow
+	d := DurationRange(rng, math.MinInt64, math.MaxInt64)
+	assert.True(t, d < math.MaxInt64)
+	assert.Equal(t, time.Duration(-5), DurationRange(rng, -5, -4))
+	assert.Panics(t, func() { DurationRange(rng, time.Second, time.Second) })
+}
+
+func Test_TimeRange(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	loc := time.FixedZone("test", 3600)
+	from := time.Date(2020, 1, 1, 0, 0, 0, 700, loc)
+	to := from.Add(48 * time.Hour)
+	firstDay := 0
+	for i := 0; i < 10000; i++ {
+		x := TimeRange(rng, from, to)
+		assert.True(t, !x.Before(from) && x.Before(to))
+		assert.Equal(t, loc, x.Location())
+		if x.Before(from.Add(24 * time.Hour)) {
+			firstDay++
+		}
+	}
+	assert.InDelta(t, 5000, firstDay, 6*math.Sqrt(2500))
+
+	// spans too long for a time.Duration, with the end's nanoseconds before the start's
+	from = time.Date(1, 1, 1, 0, 0, 0, 999999999, time.UTC)
+	to = time.Date(9999, 1, 1, 0, 0, 0, 1, time.UTC)
+	before5000 := 0
+	for i := 0; i < 10000; i++ {
+		x := TimeRange(rng, from, to)
+		assert.True(t, !x.Before(from) && x.Before(to))
+		if x.Year() < 5000 {
+			before5000++
+		}
+	}
+	assert.InDelta(t, 10000*4999/9998, before5000, 6*math.Sqrt(2500))
+
+	// a one nanosecond span always gives from
+	assert.Equal(t, from, TimeRange(rng, from, from.Add(1)))
+	assert.Panics(t, func() { TimeRange(rng, to, from) })
+}
+
+func Test_SafeRNG_DurationRange(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	d := rng.DurationRange(time.Millisecond, time.Second)
+	assert.True(t, d >= time.Millisecond && d < time.Second)
+	now := time.Now()
+	x := rng.TimeRange(now, now.Add(time.Hour))
+	assert.True(t, !x.Before(now) && x.Before(now.Add(time.Hour)))
+}
@@ -0,0 +1,49 @@
+package fastrand64
+
+// BytesBigEndian fills a []byte array with random bytes from a thread unsafe RNG
+//
+// Each Uint64 is written big endian, so the output is the big endian encoding of the generator's Uint64
+// sequence, with the last word truncated to the bytes that fit
+func BytesBigEndian(r UnsafeRNG, bytes []byte) []byte {
+	n := len(bytes)
+	i := 0
+
+	for ; i+8 <= n; i += 8 {
+		x := r.Uint64()
+		bytes[i] = byte(x >> 56)
+		bytes[i+1] = byte(x >> 48)
+		bytes[i+2] = byte(x >> 40)
+		bytes[i+3] = byte(x >> 32)
+		bytes[i+4] = byte(x >> 24)
+		bytes[i+5] = byte(x >> 16)
+		bytes[i+6] = byte(x >> 8)
+		bytes[i+7] = byte(x)
+	}
+
+	if i < n {
+		x := r.Uint64()
+		for ; i < n; i++ {
+			bytes[i] = byte(x >> 56)
+			x <<= 8
+		}
+	}
+
+	return bytes
+}
+
+// BytesBigEndian allocates a []byte filled with random bytes in big endian order and returns it. Threadsafe
+func (s *ThreadsafePoolRNG) BytesBigEndian(n int) []byte {
+	r, pool := s.get()
+	bytes := make([]byte, n)
+	result := BytesBigEndian(r, bytes)
+	pool.put(r)
+	return result
+}
+
+// ReadBigEndian fills a []byte array with random bytes in big endian order from a thread safe pool backed RNG
+func (s *ThreadsafePoolRNG) ReadBigEndian(p []byte) []byte {
+	r, pool := s.get()
+	BytesBigEndian(r, p)
+	pool.put(r)
+	return p
+}
@@ -0,0 +1,43 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// expectedStream encodes n words from a fresh rand generator seeded with 1 in the given byte order
+func expectedStream(order binary.ByteOrder, words int) []byte {
+	rng := NewUnsafeRandRNG(1)
+	b := make([]byte, words*8)
+	for i := 0; i < words; i++ {
+		order.PutUint64(b[i*8:], rng.Uint64())
+	}
+	return b
+}
+
+func Test_Bytes_LittleEndian(t *testing.T) {
+	expected := expectedStream(binary.LittleEndian, 3)
+	for n := 0; n <= 20; n++ {
+		b := Bytes(NewUnsafeRandRNG(1), make([]byte, n))
+		assert.Equal(t, expected[:n], b)
+	}
+
+	rng := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeRandRNG(1) })
+	assert.Equal(t, expected[:13], rng.Read(make([]byte, 13)))
+}
+
+func Test_BytesBigEndian(t *testing.T) {
+	expected := expectedStream(binary.BigEndian, 3)
+	for n := 0; n <= 20; n++ {
+		b := BytesBigEndian(NewUnsafeRandRNG(1), make([]byte, n))
+		assert.Equal(t, expected[:n], b)
+	}
+
+	rng := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeRandRNG(1) })
+	assert.Equal(t, expected[:13], rng.ReadBigEndian(make([]byte, 13)))
+
+	rng = NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeRandRNG(1) })
+	assert.Equal(t, expected[:21], rng.BytesBigEndian(21))
+}
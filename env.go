@@ -0,0 +1,83 @@
+package fastrand64
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Environment variables read by NewPoolFromEnv
+const (
+	// EnvAlgo names the backing generator: "xoshiro256ss" (the default), "xoshiro256ssx4", "wyrand", "chacha8", "aesctr",
+	// "lehmer128", "xoroshiro128p", "xoroshiro128ss", "pcg64", "rand", or any name passed to Register
+	EnvAlgo = "FASTRAND_ALGO"
+	// EnvSeed is a base seed (a decimal int64) that the seeds of every pooled generator are derived from
+	EnvSeed = "FASTRAND_SEED"
+	// EnvDeterministic is a bool, when true and no seed is given the base seed defaults to 0
+	EnvDeterministic = "FASTRAND_DETERMINISTIC"
+)
+
+const defaultAlgo = "xoshiro256ss"
+
+// NewPoolFromEnv builds a thread safe pool configured by the FASTRAND_ALGO, FASTRAND_SEED and
+// FASTRAND_DETERMINISTIC environment variables, so deployments and CI can change the randomness
+// behavior without code changes. Unset variables fall back to a time seeded xoshiro256** pool,
+// the same as NewSyncPoolXoshiro256ssRNG.
+//
+// When a seed is given (or FASTRAND_DETERMINISTIC is true) the n-th generator the pool creates is
+// always seeded the same way. The pool hands generators to goroutines in no particular order though,
+// so only single goroutine use is exactly reproducible.
+//
+// An error is returned for an unknown algorithm, a seed that isn't an int64, a deterministic flag
+// that isn't a bool, or a seed combined with FASTRAND_DETERMINISTIC=false
+func NewPoolFromEnv() (*ThreadsafePoolRNG, error) {
+	algo := defaultAlgo
+	if v, ok := os.LookupEnv(EnvAlgo); ok && strings.TrimSpace(v) != "" {
+		algo = strings.ToLower(strings.TrimSpace(v))
+	}
+	factory, ok := lookupGenerator(algo)
+	if !ok {
+		return nil, invalidArgument("%s %q: unknown generator", EnvAlgo, algo)
+	}
+
+	deterministic := false
+	deterministicSet := false
+	if v, ok := os.LookupEnv(EnvDeterministic); ok && strings.TrimSpace(v) != "" {
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return nil, invalidArgument("%s %q: %v", EnvDeterministic, v, err)
+		}
+		deterministic = b
+		deterministicSet = true
+	}
+
+	var seed int64
+	if v, ok := os.LookupEnv(EnvSeed); ok && strings.TrimSpace(v) != "" {
+		if deterministicSet && !deterministic {
+			return nil, invalidArgument("%s is set but %s is false", EnvSeed, EnvDeterministic)
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return nil, invalidArgument("%s %q: %v", EnvSeed, v, err)
+		}
+		seed = n
+		deterministic = true
+	}
+
+	if deterministic {
+		return newDerivedSeedPool(factory, seed), nil
+	}
+	return NewSyncPoolRNG(func() UnsafeRNG {
+		return factory(int64(freshSeed()))
+	}), nil
+}
+
+// newDerivedSeedPool seeds the n-th generator created by the pool with splitmix64(seed + n)
+func newDerivedSeedPool(fn GeneratorFactory, seed int64) *ThreadsafePoolRNG {
+	var n uint64
+	return NewSyncPoolRNG(func() UnsafeRNG {
+		i := atomic.AddUint64(&n, 1) - 1
+		return fn(int64(Splitmix64(uint64(seed) + i)))
+	})
+}
@@ -0,0 +1,51 @@
+package fastrand64
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewPoolFromEnv_Defaults(t *testing.T) {
+	t.Setenv(EnvAlgo, "")
+	t.Setenv(EnvSeed, "")
+	t.Setenv(EnvDeterministic, "")
+	rng, err := NewPoolFromEnv()
+	assert.NoError(t, err)
+	assert.Less(t, rng.Uint32n(10), uint32(10))
+}
+
+func Test_NewPoolFromEnv_Seeded(t *testing.T) {
+	t.Setenv(EnvAlgo, " WyRand ")
+	t.Setenv(EnvSeed, "42")
+	t.Setenv(EnvDeterministic, "")
+	rng, err := NewPoolFromEnv()
+	assert.NoError(t, err)
+	expected := NewUnsafeWyrandRNG(int64(Splitmix64(42)))
+	assert.Equal(t, expected.Uint64(), rng.Uint64())
+
+	t.Setenv(EnvAlgo, "chacha8")
+	t.Setenv(EnvSeed, "")
+	t.Setenv(EnvDeterministic, "true")
+	rng, err = NewPoolFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, NewUnsafeChaCha8RNG(int64(Splitmix64(0))).Uint64(), rng.Uint64())
+}
+
+func Test_NewPoolFromEnv_Errors(t *testing.T) {
+	cases := []struct{ algo, seed, deterministic string }{
+		{"mersenne", "", ""},
+		{"", "abc", ""},
+		{"", "", "maybe"},
+		{"", "1", "false"},
+	}
+	for _, c := range cases {
+		t.Setenv(EnvAlgo, c.algo)
+		t.Setenv(EnvSeed, c.seed)
+		t.Setenv(EnvDeterministic, c.deterministic)
+		rng, err := NewPoolFromEnv()
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+		assert.Nil(t, rng)
+	}
+}
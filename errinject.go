@@ -0,0 +1,112 @@
+package fastrand64
+
+import "sync"
+
+// InjectedError is one error an ErrorInjector can return, picked in proportion to Weight when a call fails
+type InjectedError struct {
+	Err    error
+	Weight float64
+}
+
+// ErrorInjectorConfig configures an ErrorInjector. Failures come in bursts by way of a two state Markov chain,
+// the Gilbert-Elliott model: calls are normal or in a burst, each state has its own failure probability, and
+// the state may flip before every call. Leave EnterBurst at 0 for independent failures
+type ErrorInjectorConfig struct {
+	// Errors are the errors to inject, at least one
+	Errors []InjectedError
+	// FailProb is the failure probability of a call outside a burst
+	FailProb float64
+	// BurstFailProb is the failure probability of a call during a burst
+	BurstFailProb float64
+	// EnterBurst is the chance per call of a burst starting, ExitBurst the chance per call of it ending.
+	// Bursts last 1/ExitBurst calls on average
+	EnterBurst, ExitBurst float64
+}
+
+// ErrorInjector returns configured errors at random from any call site, to harden retry logic against
+// realistic failure patterns. Seeded, so the same calls fail every run.
+// Threadsafe, though with concurrent callers which call gets which outcome depends on scheduling
+type ErrorInjector struct {
+	mu     sync.Mutex
+	r      *UnsafeXoshiro256ssRNG
+	cfg    ErrorInjectorConfig
+	pick   *aliasTable
+	burst  bool
+	calls  uint64
+	failed uint64
+}
+
+// NewErrorInjector creates an ErrorInjector seeded by seed, all probabilities must be in [0, 1]
+func NewErrorInjector(seed Seed, cfg ErrorInjectorConfig) (*ErrorInjector, error) {
+	weights := make([]float64, len(cfg.Errors))
+	for i, e := range cfg.Errors {
+		if e.Err == nil {
+			return nil, invalidArgument("NewErrorInjector: error %d is nil", i)
+		}
+		weights[i] = e.Weight
+	}
+	pick, err := newAliasTable("NewErrorInjector", weights)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range []float64{cfg.FailProb, cfg.BurstFailProb, cfg.EnterBurst, cfg.ExitBurst} {
+		if !(p >= 0 && p <= 1) {
+			return nil, invalidArgument("NewErrorInjector: probability %v not in [0, 1]", p)
+		}
+	}
+	cfg.Errors = append([]InjectedError(nil), cfg.Errors...)
+	return &ErrorInjector{r: NewUnsafeXoshiro256ssRNGFromSeed(seed), cfg: cfg, pick: pick}, nil
+}
+
+// Err decides the outcome of one call, returning the error to inject or nil to let the call through
+func (e *ErrorInjector) Err() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	defer e.r.handOff()
+	if e.burst {
+		e.burst = !(Float64(e.r) < e.cfg.ExitBurst)
+	} else {
+		e.burst = Float64(e.r) < e.cfg.EnterBurst
+	}
+	p := e.cfg.FailProb
+	if e.burst {
+		p = e.cfg.BurstFailProb
+	}
+	e.calls++
+	if !(Float64(e.r) < p) {
+		return nil
+	}
+	e.failed++
+	return e.cfg.Errors[e.pick.next(e.r)].Err
+}
+
+// Do returns an injected error without calling fn, or calls fn and returns its error
+func (e *ErrorInjector) Do(fn func() error) error {
+	if err := e.Err(); err != nil {
+		return err
+	}
+	return fn()
+}
+
+// InBurst reports whether the last call was made during a burst
+func (e *ErrorInjector) InBurst() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.burst
+}
+
+// Counts returns how many calls have been decided, and how many of them failed
+func (e *ErrorInjector) Counts() (calls, failed uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.calls, e.failed
+}
+
+// Inject is Do for call sites that return a value, on an injected error the zero value is returned
+func Inject[T any](e *ErrorInjector, fn func() (T, error)) (T, error) {
+	if err := e.Err(); err != nil {
+		var zero T
+		return zero, err
+	}
+	return fn()
+}
@@ -0,0 +1,93 @@
+package fastrand64
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errTimeout  = errors.New("timeout")
+	errConnLost = errors.New("connection lost")
+)
+
+func Test_NewErrorInjector_Errors(t *testing.T) {
+	seed := SeedFromString(t.Name())
+	for _, cfg := range []ErrorInjectorConfig{
+		{},
+		{Errors: []InjectedError{{nil, 1}}},
+		{Errors: []InjectedError{{errTimeout, 0}}},
+		{Errors: []InjectedError{{errTimeout, 1}}, FailProb: 1.5},
+		{Errors: []InjectedError{{errTimeout, 1}}, ExitBurst: -1},
+	} {
+		e, err := NewErrorInjector(seed, cfg)
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+		assert.Nil(t, e)
+	}
+}
+
+func Test_ErrorInjector_Independent(t *testing.T) {
+	e, err := NewErrorInjector(SeedFromString(t.Name()), ErrorInjectorConfig{
+		Errors:   []InjectedError{{errTimeout, 3}, {errConnLost, 1}},
+		FailProb: 0.2,
+	})
+	assert.NoError(t, err)
+	counts := map[error]int{}
+	for i := 0; i < 40000; i++ {
+		counts[e.Err()]++
+	}
+	assert.InDelta(t, 32000, counts[nil], 400)
+	assert.InDelta(t, 6000, counts[errTimeout], 300)
+	assert.InDelta(t, 2000, counts[errConnLost], 200)
+	calls, failed := e.Counts()
+	assert.Equal(t, uint64(40000), calls)
+	assert.Equal(t, uint64(40000-counts[nil]), failed)
+}
+
+func Test_ErrorInjector_Bursts(t *testing.T) {
+	cfg := ErrorInjectorConfig{
+		Errors:        []InjectedError{{errTimeout, 1}},
+		BurstFailProb: 1,
+		EnterBurst:    0.01,
+		ExitBurst:     0.1,
+	}
+	e, _ := NewErrorInjector(SeedFromString(t.Name()), cfg)
+	again, _ := NewErrorInjector(SeedFromString(t.Name()), cfg)
+
+	// all failures happen in bursts, which average 10 calls
+	failures, runs := 0, 0
+	prev := false
+	for i := 0; i < 100000; i++ {
+		err := e.Err()
+		assert.Equal(t, err, again.Err())
+		failing := err != nil
+		assert.Equal(t, e.InBurst(), failing)
+		if failing {
+			failures++
+			if !prev {
+				runs++
+			}
+		}
+		prev = failing
+	}
+	assert.InDelta(t, 10, float64(failures)/float64(runs), 1)
+}
+
+func Test_ErrorInjector_Do(t *testing.T) {
+	always, _ := NewErrorInjector(SeedFromString(t.Name()), ErrorInjectorConfig{Errors: []InjectedError{{errTimeout, 1}}, FailProb: 1})
+	never, _ := NewErrorInjector(SeedFromString(t.Name()), ErrorInjectorConfig{Errors: []InjectedError{{errTimeout, 1}}})
+
+	called := false
+	assert.Equal(t, errTimeout, always.Do(func() error { called = true; return nil }))
+	assert.False(t, called)
+	assert.NoError(t, never.Do(func() error { called = true; return nil }))
+	assert.True(t, called)
+
+	v, err := Inject(always, func() (int, error) { return 42, nil })
+	assert.Equal(t, errTimeout, err)
+	assert.Equal(t, 0, v)
+	v, err = Inject(never, func() (int, error) { return 42, nil })
+	assert.NoError(t, err)
+	assert.Equal(t, 42, v)
+}
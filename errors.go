@@ -0,0 +1,66 @@
+package fastrand64
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidArgument is wrapped by every error returned for a bad parameter, test for it with errors.Is.
+//
+// Constructors that take user supplied parameters return it instead of panicking: bad sizes and counts from
+// NewWeightedReservoir, NewHotKeys, NewStringPool and the other config driven generators, bad weights from
+// NewWeightedSampler, NewCategorical and WeightedShuffle, and bad seeds or state from ParseSeed and
+// NewUnsafeXoshiro256ssRNGFromState. TryNewSyncPoolRNG is the error returning NewSyncPoolRNG. The draw functions
+// such as Intn still panic on a bad n, like math/rand, since n there is rarely configuration
+var ErrInvalidArgument = errors.New("fastrand64: invalid argument")
+
+func invalidArgument(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidArgument, fmt.Sprintf(format, args...))
+}
+
+// TryNewSyncPoolRNG is NewSyncPoolRNG for callers wiring the pool up from configuration, instead of
+// failing on the first Get it returns an error if fn is nil or doesn't produce a generator.
+//
+// fn is called once up front to check it, and that generator is kept in the pool. fn may be nil if the
+// options include WithGenerator
+func TryNewSyncPoolRNG(fn func() UnsafeRNG, opts ...PoolOption) (*ThreadsafePoolRNG, error) {
+	if len(opts) > 0 {
+		s, err := newConfiguredPool(fn, opts)
+		if err != nil {
+			return nil, err
+		}
+		fn = s.fn
+		r := fn()
+		if r == nil {
+			return nil, invalidArgument("TryNewSyncPoolRNG: generator func returned nil")
+		}
+		pools := s.pools.Load()
+		if pools.stats != nil {
+			pools.stats.created.Add(1)
+		}
+		HandOff(r)
+		pools.release(r)
+		return s, nil
+	}
+	if fn == nil {
+		return nil, invalidArgument("TryNewSyncPoolRNG: nil generator func")
+	}
+	r := fn()
+	if r == nil {
+		return nil, invalidArgument("TryNewSyncPoolRNG: generator func returned nil")
+	}
+	s := NewSyncPoolRNG(fn)
+	HandOff(r)
+	s.pools.Load().release(r)
+	return s, nil
+}
+
+// NewUnsafeXoshiro256ssRNGFromState creates a new Thread unsafe PRNG generator from an explicit 256 bit state,
+// for example one saved from another run. The all zero state is rejected since xoshiro256** would only ever
+// produce zeros from it
+func NewUnsafeXoshiro256ssRNGFromState(state [4]uint64) (*UnsafeXoshiro256ssRNG, error) {
+	if state[0]|state[1]|state[2]|state[3] == 0 {
+		return nil, invalidArgument("NewUnsafeXoshiro256ssRNGFromState: all zero state")
+	}
+	return &UnsafeXoshiro256ssRNG{s0: state[0], s1: state[1], s2: state[2], s3: state[3]}, nil
+}
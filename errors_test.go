@@ -0,0 +1,50 @@
+package fastrand64
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsuna/endian"
+)
+
+func Test_TryNewSyncPoolRNG(t *testing.T) {
+	rng, err := TryNewSyncPoolRNG(nil)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	assert.Nil(t, rng)
+
+	rng, err = TryNewSyncPoolRNG(func() UnsafeRNG { return nil })
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	assert.Nil(t, rng)
+
+	rng, err = TryNewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeRandRNG(1) })
+	assert.NoError(t, err)
+	assert.Equal(t, NewUnsafeRandRNG(1).Uint64(), rng.Uint64())
+
+	// the generator made to check fn counts as created, and isn't in use
+	rng, err = TryNewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeRandRNG(1) }, WithStats())
+	assert.NoError(t, err)
+	assert.Equal(t, PoolStats{Created: 1}, rng.Stats())
+}
+
+func Test_NewUnsafeXoshiro256ssRNGFromState(t *testing.T) {
+	rng, err := NewUnsafeXoshiro256ssRNGFromState([4]uint64{})
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	assert.Nil(t, rng)
+
+	rng, err = NewUnsafeXoshiro256ssRNGFromState([4]uint64{0x01d353e5f3993bb0, 0x7b9c0df6cb193b20, 0xfdfcaa91110765b6, 0xd2db341f10bb232e})
+	assert.NoError(t, err)
+	assert.Equal(t, endian.HostToNetUint64(uint64(0xdd51b2b7d9303a37)), rng.Uint64())
+}
+
+func Test_Constructors_ErrInvalidArgument(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	_, err := NewWeightedReservoir[int](rng, 0)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	_, err = NewWeightedSampler(rng, []float64{1, -1})
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	_, err = NewCategorical(rng, []float64{0, 0})
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	_, err = ParseSeed("not hex")
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+}
@@ -0,0 +1,89 @@
+package fastrand64
+
+import "math"
+
+// Ziggurat tables for the standard exponential distribution, see
+// Marsaglia & Tsang, "The Ziggurat Method for Generating Random Variables" (2000)
+const (
+	expLayers = 256
+	expR      = 7.69711747013104972  // start of the tail
+	expV      = 3.949659822581572e-3 // area of each layer
+	expLayerM = expLayers - 1        // mask to pick a layer from the low bits
+	expScale  = 1.0 / (1 << 53)      // turns 53 bits into a float in [0, 1)
+)
+
+var (
+	// expX[i] is the right edge of layer i, expX[0] is the width of the virtual base layer
+	expX [expLayers + 1]float64
+	// expRatio[i] is expX[i+1]/expX[i], anything inside it is accepted without evaluating exp
+	expRatio [expLayers]float64
+)
+
+func init() {
+	f := math.Exp(-expR)
+	expX[0] = expV / f
+	expX[1] = expR
+	expX[expLayers] = 0
+	for i := 2; i < expLayers; i++ {
+		expX[i] = -math.Log(expV/expX[i-1] + f)
+		f = math.Exp(-expX[i])
+	}
+	for i := 0; i < expLayers; i++ {
+		expRatio[i] = expX[i+1] / expX[i]
+	}
+}
+
+// ExpFloat64 returns an exponentially distributed float64 with rate 1 (so mean 1)
+// from a thread unsafe RNG, using the Ziggurat method
+//
+// Almost every call costs a single Uint64, the low 8 bits pick the layer and the top 53 bits the position
+func ExpFloat64(r UnsafeRNG) float64 {
+	for {
+		x := r.Uint64()
+		i := x & expLayerM
+		u := float64(x>>11) * expScale
+		if u < expRatio[i] {
+			return u * expX[i]
+		}
+		if i == 0 {
+			// the exponential distribution is memoryless, so the tail is just another exponential shifted by expR
+			return expR - math.Log(Float64OpenClosed(r))
+		}
+		// in the wedge between the rectangle and the curve, so test against the density itself
+		z := u * expX[i]
+		f0 := math.Exp(-expX[i])
+		f1 := math.Exp(-expX[i+1])
+		if f0+Float64(r)*(f1-f0) < math.Exp(-z) {
+			return z
+		}
+	}
+}
+
+// ExpFloat64Rate returns an exponentially distributed float64 with the given rate (so mean 1/rate)
+// from a thread unsafe RNG
+//
+// It panics if rate <= 0
+func ExpFloat64Rate(r UnsafeRNG, rate float64) float64 {
+	if !(rate > 0) {
+		panic("invalid argument to ExpFloat64Rate")
+	}
+	return ExpFloat64(r) / rate
+}
+
+// ExpFloat64 returns an exponentially distributed float64 with rate 1 (so mean 1). Threadsafe
+func (s *ThreadsafePoolRNG) ExpFloat64() float64 {
+	r, pool := s.get()
+	x := ExpFloat64(r)
+	pool.put(r)
+	return x
+}
+
+// ExpFloat64Rate returns an exponentially distributed float64 with the given rate (so mean 1/rate). Threadsafe
+//
+// It panics if rate <= 0
+func (s *ThreadsafePoolRNG) ExpFloat64Rate(rate float64) float64 {
+	r, pool := s.get()
+	x := ExpFloat64Rate(r, rate)
+	pool.put(r)
+	return x
+}
@@ -0,0 +1,35 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ExpFloat64(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	tail := 0
+	mean, variance := sampleMoments(1000000, func() float64 {
+		x := ExpFloat64(rng)
+		assert.GreaterOrEqual(t, x, 0.0)
+		if x > expR {
+			tail++
+		}
+		return x
+	})
+	assert.InDelta(t, 1.0, mean, 0.01)
+	assert.InDelta(t, 1.0, variance, 0.02)
+	// P(x > 7.697) is about 0.000453
+	assert.InDelta(t, 453, tail, 120)
+}
+
+func Test_SafeRNG_ExpFloat64Rate(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	mean, _ := sampleMoments(100000, func() float64 { return rng.ExpFloat64Rate(4) })
+	assert.InDelta(t, 0.25, mean, 0.01)
+
+	mean, _ = sampleMoments(100000, rng.ExpFloat64)
+	assert.InDelta(t, 1.0, mean, 0.02)
+
+	assert.Panics(t, func() { rng.ExpFloat64Rate(0) })
+}
@@ -24,12 +24,25 @@ package fastrand64
 import (
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // ThreadsafePoolRNG core type for the pool backed threadsafe RNG
 type ThreadsafePoolRNG struct {
+	pools  atomic.Pointer[rngPools]
+	fn     func() UnsafeRNG
+	mark   atomic.Pointer[processMark]
+	stats  *poolStats // nil unless WithStats
+	pinned int        // generators kept out of reach of GC, see WithPinned
+}
+
+// rngPools holds everything derived from generator state, so it can all be thrown away at once
+type rngPools struct {
 	rngPool sync.Pool
+	bitPool sync.Pool
+	stats   *poolStats
+	pinned  []pinnedSlot // tried before rngPool, when WithPinned
 }
 
 // UnsafeRNG is the interface for an unsafe RNG used by the Pool RNG as a source of randomness
@@ -38,12 +51,76 @@ type UnsafeRNG interface {
 }
 
 // NewSyncPoolRNG Wraps a sync.Pool around a thread unsafe RNG, thus making it efficiently thread safe
-func NewSyncPoolRNG(fn func() UnsafeRNG) *ThreadsafePoolRNG {
-	s := &ThreadsafePoolRNG{}
-	s.rngPool = sync.Pool{New: func() interface{} { return fn() }}
+//
+// Options can prewarm the pool, pick the generator by name, or change how it is seeded. It panics if the
+// options are invalid, use TryNewSyncPoolRNG to get an error instead
+func NewSyncPoolRNG(fn func() UnsafeRNG, opts ...PoolOption) *ThreadsafePoolRNG {
+	if len(opts) > 0 {
+		s, err := newConfiguredPool(fn, opts)
+		if err != nil {
+			panic(err)
+		}
+		return s
+	}
+	s := &ThreadsafePoolRNG{fn: fn}
+	s.pools.Store(s.newPools(fn))
+	s.mark.Store(currentProcessMark())
 	return s
 }
 
+// newPools makes empty pools of generators from fn, with the pool's stats and pinned slots
+func (s *ThreadsafePoolRNG) newPools(fn func() UnsafeRNG) *rngPools {
+	stats := s.stats
+	p := &rngPools{stats: stats}
+	if s.pinned > 0 {
+		p.pinned = make([]pinnedSlot, s.pinned)
+	}
+	p.rngPool.New = func() interface{} {
+		if stats != nil {
+			stats.created.Add(1)
+		}
+		return fn()
+	}
+	return p
+}
+
+// get checks a generator out of the pool, it must be handed back with put on the returned pools
+func (s *ThreadsafePoolRNG) get() (UnsafeRNG, *rngPools) {
+	p := s.pools.Load()
+	return p.get(), p
+}
+
+// get checks a generator out of these pools, it must be handed back with put
+func (p *rngPools) get() UnsafeRNG {
+	if p.stats != nil {
+		p.stats.gets.Add(1)
+		p.stats.inUse.Add(1)
+	}
+	if p.pinned != nil {
+		if r := p.takePinned(); r != nil {
+			return r
+		}
+	}
+	return p.rngPool.Get().(UnsafeRNG)
+}
+
+// put hands a generator back to the pools it came from, if those have since been replaced it is just dropped
+func (p *rngPools) put(r UnsafeRNG) {
+	HandOff(r)
+	if p.stats != nil {
+		p.stats.inUse.Add(-1)
+	}
+	p.release(r)
+}
+
+// release stores an idle generator, in a pinned slot if one is free
+func (p *rngPools) release(r UnsafeRNG) {
+	if p.pinned != nil && p.pin(r) {
+		return
+	}
+	p.rngPool.Put(r)
+}
+
 // NewSyncPoolXoshiro256ssRNG conveniently allocations a thread safe pooled back xoshiro256** generator
 // this uses NewSyncPoolRNG internally
 func NewSyncPoolXoshiro256ssRNG() *ThreadsafePoolRNG {
@@ -53,11 +130,17 @@ func NewSyncPoolXoshiro256ssRNG() *ThreadsafePoolRNG {
 	})
 }
 
+// defaultRNG backs the package level functions that don't take a generator, its generators are seeded from
+// crypto/rand as they are made
+var defaultRNG = NewSyncPoolRNG(func() UnsafeRNG {
+	return NewUnsafeXoshiro256ssRNG(int64(freshSeed()))
+})
+
 // Uint64 returns pseudorandom uint64. Threadsafe
 func (s *ThreadsafePoolRNG) Uint64() uint64 {
-	r := s.rngPool.Get().(UnsafeRNG)
+	r, pool := s.get()
 	x := r.Uint64()
-	s.rngPool.Put(r)
+	pool.put(r)
 	return x
 }
 
@@ -66,36 +149,47 @@ func (s *ThreadsafePoolRNG) Int63() int64 {
 	return int64(0x7FFFFFFFFFFFFFFF & s.Uint64())
 }
 
-// Seed is only here to match the golang std libs Source64 interface
+// Seed is here to match the golang std libs Source64 interface, it calls ReseedAll. Read its caveat, seeding a
+// pool doesn't make the order of its output repeatable the way seeding a single generator does
 func (s *ThreadsafePoolRNG) Seed(seed int64) {
-	// you cant really seed a PoolRNG, since the call order is non-determinate
-	panic("Cant seed a ThreadsafePoolRNG")
+	s.ReseedAll(seed)
 }
 
 // Bytes allocates a []byte filled with random bytes and returns it. This is convenient
 // but caller does the allocation pattern is better way since it can reduce allocation count/GC
+//
+// Above a few MB the work is split across GOMAXPROCS goroutines, each with its own pooled generator
 func (s *ThreadsafePoolRNG) Bytes(n int) []byte {
-	r := s.rngPool.Get().(UnsafeRNG)
 	bytes := make([]byte, n)
-	result := Bytes(r, bytes)
-	s.rngPool.Put(r)
-	return result
+	s.fill(bytes)
+	return bytes
 }
 
-// Read fills a []byte array with random bytes from a thread safe pool backed RNG
+// Read fills a []byte array with random bytes from a thread safe pool backed RNG, in the same little endian
+// order as Bytes
+//
+// Above a few MB the work is split across GOMAXPROCS goroutines, each with its own pooled generator, so the
+// buffer is then the concatenation of several generators' output
 func (s *ThreadsafePoolRNG) Read(p []byte) []byte {
-	r := s.rngPool.Get().(UnsafeRNG)
-	Bytes(r, p)
-	s.rngPool.Put(r)
+	s.fill(p)
 	return p
 }
 
 // Bytes fills a []byte array with random bytes from a thread unsafe RNG
+//
+// The byte order is guaranteed: each Uint64 is written little endian, so the output is the little endian
+// encoding of the generator's Uint64 sequence, with the last word truncated to the bytes that fit
 func Bytes(r UnsafeRNG, bytes []byte) []byte {
 	n := len(bytes)
 	bytesToGo := n
 	i := 0
 
+	if b, ok := r.(bulkRNG); ok {
+		i = n &^ 7
+		bytesToGo -= i
+		b.fillBytes(bytes[:i])
+	}
+
 	for {
 		if bytesToGo < 8 {
 			break
@@ -143,6 +237,7 @@ func (s *ThreadsafePoolRNG) Uint32n(maxN int) uint32 {
 //
 // It is however very fast, and strong enough for most practical purposes
 type UnsafeXoshiro256ssRNG struct {
+	owner
 	s0 uint64
 	s1 uint64
 	s2 uint64
@@ -165,6 +260,7 @@ func Splitmix64(index uint64) uint64 {
 
 // Uint64 generates a random Uin64, (not thread safe)
 func (r *UnsafeXoshiro256ssRNG) Uint64() uint64 {
+	r.checkOwner("UnsafeXoshiro256ssRNG")
 	// See https://en.wikipedia.org/wiki/Xorshift
 	result := rol64(r.s1*5, 7) * 9
 	t := r.s1 << 17
@@ -197,6 +293,11 @@ func (r *UnsafeXoshiro256ssRNG) Seed(seed int64) {
 	}
 }
 
+// State returns the 256 bit state of the RNG, it can be restored with NewUnsafeXoshiro256ssRNGFromState
+func (r *UnsafeXoshiro256ssRNG) State() [4]uint64 {
+	return [4]uint64{r.s0, r.s1, r.s2, r.s3}
+}
+
 // NewUnsafeXoshiro256ssRNG creates a new Thread unsafe PRNG generator
 func NewUnsafeXoshiro256ssRNG(seed int64) *UnsafeXoshiro256ssRNG {
 	r := &UnsafeXoshiro256ssRNG{}
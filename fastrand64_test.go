@@ -39,7 +39,17 @@ func Test_SafeRNG_UInt64(t *testing.T) {
 
 func Test_SafeRNG_Seed(t *testing.T) {
 	rng := NewSyncPoolXoshiro256ssRNG()
-	assert.Panics(t, func() { rng.Seed(0) })
+	assert.NotPanics(t, func() { rng.Seed(0) })
+	// Seed empties the pool, so whatever sync.Pool does the next call gets the first generator made after it
+	var x uint64
+	rng.With(func(r UnsafeRNG) { x = r.Uint64() })
+	assert.Equal(t, NewUnsafeXoshiro256ssRNG(int64(Uint64At(Uint64At(0, 0), 0))).Uint64(), x)
+
+	// later generators get their own seeds, so only check a reseeded pool keeps producing
+	for i := 0; i < 100; i++ {
+		rng.Seed(int64(i))
+		assert.NotEqual(t, rng.Uint64(), rng.Uint64())
+	}
 }
 
 func Test_SafeRNG_Int63(t *testing.T) {
@@ -0,0 +1,113 @@
+package fastrand64
+
+import (
+	"math/bits"
+	"strconv"
+)
+
+// permutationRounds is the number of Feistel rounds, 4 is the minimum for a good pseudorandom permutation and a
+// couple more are cheap insurance since the round function is only a hash
+const permutationRounds = 6
+
+// Permutation is a random bijection of [0..n) that is computed on demand rather than materialized, so randomized
+// scans over billions of keys need O(1) memory. It is a keyed Feistel network over the smallest even number of bits
+// that covers n, with cycle walking to stay inside [0..n).
+//
+// It is immutable, so safe to use from concurrent goroutines. It isn't a cryptographic cipher
+type Permutation struct {
+	n        uint64
+	halfBits uint
+	halfMask uint64
+	keys     [permutationRounds]uint64
+}
+
+// NewPermutation creates a random permutation of [0..n) determined entirely by seed
+func NewPermutation(n uint64, seed Seed) *Permutation {
+	p := &Permutation{n: n}
+	width := uint(bits.Len64(n - 1))
+	if n <= 1 {
+		width = 0
+	}
+	p.halfBits = (width + 1) / 2
+	if p.halfBits == 0 {
+		p.halfBits = 1
+	}
+	p.halfMask = 1<<p.halfBits - 1
+	for i := range p.keys {
+		p.keys[i] = seed.DeriveUint64("feistel/" + strconv.Itoa(i))
+	}
+	return p
+}
+
+// Len returns n, the size of the permuted domain
+func (p *Permutation) Len() uint64 {
+	return p.n
+}
+
+func (p *Permutation) round(x uint64, key uint64) uint64 {
+	return Splitmix64(x^key) & p.halfMask
+}
+
+func (p *Permutation) encrypt(x uint64) uint64 {
+	left := x >> p.halfBits
+	right := x & p.halfMask
+	for _, k := range p.keys {
+		left, right = right, left^p.round(right, k)
+	}
+	return left<<p.halfBits | right
+}
+
+func (p *Permutation) decrypt(x uint64) uint64 {
+	left := x >> p.halfBits
+	right := x & p.halfMask
+	for i := permutationRounds - 1; i >= 0; i-- {
+		left, right = right^p.round(left, p.keys[i]), left
+	}
+	return left<<p.halfBits | right
+}
+
+// At returns the element at position i of the permutation, it panics if i >= n
+func (p *Permutation) At(i uint64) uint64 {
+	if i >= p.n {
+		panic("invalid argument to At")
+	}
+	// the Feistel domain is less than 4n, so this walks fewer than 4 steps on average
+	x := p.encrypt(i)
+	for x >= p.n {
+		x = p.encrypt(x)
+	}
+	return x
+}
+
+// IndexOf is the inverse of At, it returns the position of x in the permutation. It panics if x >= n
+func (p *Permutation) IndexOf(x uint64) uint64 {
+	if x >= p.n {
+		panic("invalid argument to IndexOf")
+	}
+	i := p.decrypt(x)
+	for i >= p.n {
+		i = p.decrypt(i)
+	}
+	return i
+}
+
+// PermutationIterator walks a Permutation in order, it is unsafe to share between goroutines
+type PermutationIterator struct {
+	p *Permutation
+	i uint64
+}
+
+// Iter returns an iterator over the whole permutation
+func (p *Permutation) Iter() *PermutationIterator {
+	return &PermutationIterator{p: p}
+}
+
+// Next returns the next element, and false once the permutation is exhausted
+func (it *PermutationIterator) Next() (uint64, bool) {
+	if it.i >= it.p.n {
+		return 0, false
+	}
+	x := it.p.At(it.i)
+	it.i++
+	return x, true
+}
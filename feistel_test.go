@@ -0,0 +1,50 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Permutation_Bijection(t *testing.T) {
+	for _, n := range []uint64{1, 2, 3, 7, 16, 1000, 4097} {
+		p := NewPermutation(n, SeedFromInt64(42))
+		assert.Equal(t, n, p.Len())
+		seen := make([]bool, n)
+		it := p.Iter()
+		count := uint64(0)
+		for x, ok := it.Next(); ok; x, ok = it.Next() {
+			assert.Less(t, x, n)
+			assert.False(t, seen[x])
+			seen[x] = true
+			assert.Equal(t, count, p.IndexOf(x))
+			count++
+		}
+		assert.Equal(t, n, count)
+	}
+}
+
+func Test_Permutation_Seeds(t *testing.T) {
+	a := NewPermutation(1000, SeedFromInt64(1))
+	b := NewPermutation(1000, SeedFromInt64(1))
+	c := NewPermutation(1000, SeedFromInt64(2))
+	same := 0
+	for i := uint64(0); i < 1000; i++ {
+		assert.Equal(t, a.At(i), b.At(i))
+		if a.At(i) == c.At(i) {
+			same++
+		}
+	}
+	assert.Less(t, same, 20)
+	assert.Panics(t, func() { a.At(1000) })
+	assert.Panics(t, func() { NewPermutation(0, SeedFromInt64(1)).At(0) })
+}
+
+func Test_Permutation_Huge(t *testing.T) {
+	p := NewPermutation(1<<62+12345, SeedFromInt64(7))
+	for i := uint64(0); i < 1000; i++ {
+		x := p.At(i)
+		assert.Less(t, x, uint64(1<<62+12345))
+		assert.Equal(t, i, p.IndexOf(x))
+	}
+}
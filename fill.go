@@ -0,0 +1,71 @@
+package fastrand64
+
+import "unsafe"
+
+// FillUint64s fills dst with pseudorandom uint64s from a thread unsafe RNG, and returns it
+func FillUint64s(r UnsafeRNG, dst []uint64) []uint64 {
+	if b, ok := r.(bulkRNG); ok {
+		b.fillUint64s(dst)
+		return dst
+	}
+	for i := range dst {
+		dst[i] = r.Uint64()
+	}
+	return dst
+}
+
+// FillInt64s fills dst with pseudorandom int64s covering the full range, negative values included,
+// from a thread unsafe RNG, and returns it
+func FillInt64s(r UnsafeRNG, dst []int64) []int64 {
+	if b, ok := r.(bulkRNG); ok && len(dst) > 0 {
+		b.fillUint64s(unsafe.Slice((*uint64)(unsafe.Pointer(&dst[0])), len(dst)))
+		return dst
+	}
+	for i := range dst {
+		dst[i] = int64(r.Uint64())
+	}
+	return dst
+}
+
+// FillFloat64s fills dst with pseudorandom float64s in the range [0.0, 1.0) from a thread unsafe RNG, and returns it
+func FillFloat64s(r UnsafeRNG, dst []float64) []float64 {
+	if b, ok := r.(bulkRNG); ok && len(dst) > 0 {
+		// fill the raw words in bulk, then convert them in place
+		words := unsafe.Slice((*uint64)(unsafe.Pointer(&dst[0])), len(dst))
+		b.fillUint64s(words)
+		for i, x := range words {
+			dst[i] = float64(x>>11) / (1 << 53)
+		}
+		return dst
+	}
+	for i := range dst {
+		dst[i] = float64(r.Uint64()>>11) / (1 << 53)
+	}
+	return dst
+}
+
+// FillUint64s fills dst with pseudorandom uint64s, checking a generator out of the pool only once. Threadsafe
+func (s *ThreadsafePoolRNG) FillUint64s(dst []uint64) []uint64 {
+	r, pool := s.get()
+	FillUint64s(r, dst)
+	pool.put(r)
+	return dst
+}
+
+// FillInt64s fills dst with pseudorandom int64s covering the full range, checking a generator out of the pool
+// only once. Threadsafe
+func (s *ThreadsafePoolRNG) FillInt64s(dst []int64) []int64 {
+	r, pool := s.get()
+	FillInt64s(r, dst)
+	pool.put(r)
+	return dst
+}
+
+// FillFloat64s fills dst with pseudorandom float64s in the range [0.0, 1.0), checking a generator out of the pool
+// only once. Threadsafe
+func (s *ThreadsafePoolRNG) FillFloat64s(dst []float64) []float64 {
+	r, pool := s.get()
+	FillFloat64s(r, dst)
+	pool.put(r)
+	return dst
+}
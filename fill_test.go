@@ -0,0 +1,45 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Fills_MatchSequence(t *testing.T) {
+	u := FillUint64s(NewUnsafeXoshiro256ssRNG(1), make([]uint64, 9))
+	i := FillInt64s(NewUnsafeXoshiro256ssRNG(1), make([]int64, 9))
+	f := FillFloat64s(NewUnsafeXoshiro256ssRNG(1), make([]float64, 9))
+	r := NewUnsafeXoshiro256ssRNG(1)
+	for j := range u {
+		x := r.Uint64()
+		assert.Equal(t, x, u[j])
+		assert.Equal(t, int64(x), i[j])
+		assert.Equal(t, float64(x>>11)/(1<<53), f[j])
+	}
+}
+
+func Test_SafeRNG_Fills(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	negative := false
+	for _, x := range rng.FillInt64s(make([]int64, 256)) {
+		negative = negative || x < 0
+	}
+	assert.True(t, negative)
+	for _, x := range rng.FillFloat64s(make([]float64, 256)) {
+		assert.GreaterOrEqual(t, x, 0.0)
+		assert.Less(t, x, 1.0)
+	}
+	u := rng.FillUint64s(make([]uint64, 256))
+	assert.NotEqual(t, u[0], u[1])
+	assert.Empty(t, rng.FillUint64s(nil))
+}
+
+func Benchmark_SyncPoolXoshiro256ssRNG_FillUint64s_1024(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	dst := make([]uint64, 1024)
+	for i := 0; i < b.N; i++ {
+		rng.FillUint64s(dst)
+	}
+	BenchSink = &dst
+}
@@ -0,0 +1,428 @@
+package fastrand64
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FillTag is the struct tag Fill reads, a comma separated list of:
+//
+//   - "-" to leave the field alone
+//   - min=X, max=Y: inclusive bounds for ints, uints and durations ("1s"), [min, max) for floats, and RFC 3339
+//     bounds for time.Time. They apply to the elements of slices, arrays, maps and pointers, map keys always
+//     get the defaults
+//   - len=N: the exact length of a string, slice or map
+//   - minlen=N, maxlen=N: the length range of a string, slice or map
+//   - alphabet=NAME: hex, base62 or urlsafe, the characters strings are made of
+//   - chars=ABC: any other characters to make strings of, no commas
+//
+// For example
+//
+//	type User struct {
+//		Name  string        `fastrand:"len=8,alphabet=hex"`
+//		Age   int           `fastrand:"min=18,max=99"`
+//		Tags  []string      `fastrand:"minlen=1,maxlen=3,chars=abc"`
+//		TTL   time.Duration `fastrand:"min=1s,max=1h"`
+//		Cache *Cache        `fastrand:"-"`
+//	}
+const FillTag = "fastrand"
+
+// Without tags Fill uses these
+const (
+	fillDefaultMinString = 1
+	fillDefaultMaxString = 16
+	fillDefaultMinLen    = 1
+	fillDefaultMaxLen    = 4
+	// fillMaxDepth stops self referencing types, pointers, slices and maps nested deeper are left nil
+	fillMaxDepth = 8
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	fillDefaultFrom   = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	fillDefaultTo     = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	fillDefaultSpec   = fillSpec{minLen: -1, maxLen: -1}
+	fillAlphabetNames = map[string]*Alphabet{"hex": AlphabetHex, "base62": AlphabetBase62, "urlsafe": AlphabetURLSafe}
+)
+
+// fillSpec is a parsed FillTag
+type fillSpec struct {
+	skip           bool
+	ints           *[2]int64
+	uints          *[2]uint64
+	floats         *[2]float64
+	times          *[2]time.Time
+	minLen, maxLen int // -1 for the defaults
+	alphabet       *Alphabet
+}
+
+type fillField struct {
+	index int
+	spec  fillSpec
+}
+
+type fillPlan struct {
+	fields []fillField
+	err    error
+}
+
+// fillPlans caches the parsed tags of each struct type, a *fillPlan by reflect.Type
+var fillPlans sync.Map
+
+// Fill sets everything reachable from ptr, which must be a non-nil pointer, to random values from a thread unsafe
+// RNG: numbers, bools, strings, time.Times, and the elements of structs, arrays, slices, maps and pointers, which
+// are allocated as needed. Unexported fields, interfaces, chans and funcs are left alone.
+//
+// Without tags ints and uints cover their whole range, floats are in [0, 1), times in [2000, 2030), strings are
+// 1 to 16 base62 characters, and slices and maps have 1 to 4 elements. See FillTag to change that.
+// Maps can end up shorter if a random key repeats.
+//
+// An error is returned for a bad ptr or tag, in which case ptr may be partly filled
+func Fill(r UnsafeRNG, ptr interface{}) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return invalidArgument("Fill: need a non-nil pointer, got %T", ptr)
+	}
+	return fillValue(r, v.Elem(), &fillDefaultSpec, 0)
+}
+
+// Fill sets everything reachable from ptr to random values, see the Fill function. It checks a generator out of
+// the pool only once. Threadsafe
+func (s *ThreadsafePoolRNG) Fill(ptr interface{}) error {
+	r, pool := s.get()
+	err := Fill(r, ptr)
+	pool.put(r)
+	return err
+}
+
+func fillValue(r UnsafeRNG, v reflect.Value, spec *fillSpec, depth int) error {
+	t := v.Type()
+	if t == timeType {
+		from, to := fillDefaultFrom, fillDefaultTo
+		if spec.times != nil {
+			from, to = spec.times[0], spec.times[1]
+		}
+		v.Set(reflect.ValueOf(TimeRange(r, from, to)))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Uint64()&1 == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if spec.ints == nil {
+			// SetInt truncates, which keeps it uniform
+			v.SetInt(int64(r.Uint64()))
+		} else {
+			v.SetInt(spec.ints[0] + int64(fillSpan(r, uint64(spec.ints[1])-uint64(spec.ints[0]))))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if spec.uints == nil {
+			v.SetUint(r.Uint64())
+		} else {
+			v.SetUint(spec.uints[0] + fillSpan(r, spec.uints[1]-spec.uints[0]))
+		}
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(fillFloat(r, spec, v.Kind() == reflect.Float32))
+	case reflect.Complex64, reflect.Complex128:
+		f32 := v.Kind() == reflect.Complex64
+		v.SetComplex(complex(fillFloat(r, spec, f32), fillFloat(r, spec, f32)))
+	case reflect.String:
+		a := spec.alphabet
+		if a == nil {
+			a = AlphabetBase62
+		}
+		v.SetString(a.String(r, fillLen(r, spec, fillDefaultMinString, fillDefaultMaxString)))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := fillValue(r, v.Index(i), spec, depth+1); err != nil {
+				return err
+			}
+		}
+	case reflect.Pointer:
+		if depth >= fillMaxDepth {
+			return nil
+		}
+		p := reflect.New(t.Elem())
+		if err := fillValue(r, p.Elem(), spec, depth+1); err != nil {
+			return err
+		}
+		v.Set(p)
+	case reflect.Slice:
+		if depth >= fillMaxDepth {
+			return nil
+		}
+		n := fillLen(r, spec, fillDefaultMinLen, fillDefaultMaxLen)
+		s := reflect.MakeSlice(t, n, n)
+		if t.Elem().Kind() == reflect.Uint8 && spec.uints == nil {
+			Bytes(r, s.Bytes())
+		} else {
+			for i := 0; i < n; i++ {
+				if err := fillValue(r, s.Index(i), spec, depth+1); err != nil {
+					return err
+				}
+			}
+		}
+		v.Set(s)
+	case reflect.Map:
+		if depth >= fillMaxDepth {
+			return nil
+		}
+		n := fillLen(r, spec, fillDefaultMinLen, fillDefaultMaxLen)
+		m := reflect.MakeMapWithSize(t, n)
+		for i := 0; i < n; i++ {
+			k := reflect.New(t.Key()).Elem()
+			e := reflect.New(t.Elem()).Elem()
+			// the tag was checked against the element type, so keys get the defaults
+			if err := fillValue(r, k, &fillDefaultSpec, depth+1); err != nil {
+				return err
+			}
+			if err := fillValue(r, e, spec, depth+1); err != nil {
+				return err
+			}
+			m.SetMapIndex(k, e)
+		}
+		v.Set(m)
+	case reflect.Struct:
+		plan := fillPlanFor(t)
+		if plan.err != nil {
+			return plan.err
+		}
+		for i := range plan.fields {
+			f := &plan.fields[i]
+			if err := fillValue(r, v.Field(f.index), &f.spec, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fillSpan returns a value in [0..span], span may be the whole uint64 range
+func fillSpan(r UnsafeRNG, span uint64) uint64 {
+	if span == math.MaxUint64 {
+		return r.Uint64()
+	}
+	return Uint64n(r, span+1)
+}
+
+func fillFloat(r UnsafeRNG, spec *fillSpec, f32 bool) float64 {
+	min, max := 0.0, 1.0
+	if spec.floats != nil {
+		min, max = spec.floats[0], spec.floats[1]
+	}
+	for {
+		x := Float64Range(r, min, max)
+		if !f32 {
+			return x
+		}
+		// rounding to float32 can land on max
+		if x32 := float64(float32(x)); x32 < max && x32 >= min {
+			return x32
+		}
+	}
+}
+
+func fillLen(r UnsafeRNG, spec *fillSpec, defaultMin, defaultMax int) int {
+	min, max := spec.minLen, spec.maxLen
+	if min < 0 {
+		min = defaultMin
+		if max >= 0 && max < min {
+			min = max
+		}
+	}
+	if max < 0 {
+		max = defaultMax
+		if max < min {
+			max = min
+		}
+	}
+	return min + int(Uint64n(r, uint64(max-min)+1))
+}
+
+func fillPlanFor(t reflect.Type) *fillPlan {
+	if p, ok := fillPlans.Load(t); ok {
+		return p.(*fillPlan)
+	}
+	plan := &fillPlan{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		spec, err := parseFillTag(f.Tag.Get(FillTag), f.Type)
+		if err != nil {
+			plan.err = invalidArgument("Fill: %v.%s: %v", t, f.Name, err)
+			break
+		}
+		if !spec.skip {
+			plan.fields = append(plan.fields, fillField{index: i, spec: spec})
+		}
+	}
+	p, _ := fillPlans.LoadOrStore(t, plan)
+	return p.(*fillPlan)
+}
+
+// fillLeaf returns the type min and max apply to, under any pointers, slices, arrays and maps
+func fillLeaf(t reflect.Type) reflect.Type {
+	for t != timeType {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+	return t
+}
+
+func parseFillTag(tag string, t reflect.Type) (fillSpec, error) {
+	spec := fillDefaultSpec
+	if tag == "" {
+		return spec, nil
+	}
+	if tag == "-" {
+		spec.skip = true
+		return spec, nil
+	}
+	var min, max string
+	for _, opt := range strings.Split(tag, ",") {
+		key, value, ok := strings.Cut(opt, "=")
+		if !ok {
+			return spec, invalidArgument("bad option %q", opt)
+		}
+		var err error
+		switch key {
+		case "min":
+			min = value
+		case "max":
+			max = value
+		case "len":
+			spec.minLen, err = parseFillLen(value)
+			spec.maxLen = spec.minLen
+		case "minlen":
+			spec.minLen, err = parseFillLen(value)
+		case "maxlen":
+			spec.maxLen, err = parseFillLen(value)
+		case "alphabet":
+			if spec.alphabet = fillAlphabetNames[value]; spec.alphabet == nil {
+				return spec, invalidArgument("unknown alphabet %q", value)
+			}
+		case "chars":
+			spec.alphabet, err = NewAlphabet(value)
+		default:
+			return spec, invalidArgument("unknown option %q", key)
+		}
+		if err != nil {
+			return spec, err
+		}
+	}
+	if spec.minLen >= 0 && spec.maxLen >= 0 && spec.minLen > spec.maxLen {
+		return spec, invalidArgument("minlen %d > maxlen %d", spec.minLen, spec.maxLen)
+	}
+	if min == "" && max == "" {
+		return spec, nil
+	}
+	return spec, parseFillRange(&spec, fillLeaf(t), min, max)
+}
+
+func parseFillLen(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err == nil && n < 0 {
+		err = invalidArgument("negative length %d", n)
+	}
+	return n, err
+}
+
+// parseFillRange parses min and max for the leaf type, either may be "" for the type's own limit
+func parseFillRange(spec *fillSpec, leaf reflect.Type, min, max string) error {
+	if leaf == timeType {
+		from, to := fillDefaultFrom, fillDefaultTo
+		var err error
+		if min != "" {
+			if from, err = time.Parse(time.RFC3339Nano, min); err != nil {
+				return err
+			}
+		}
+		if max != "" {
+			if to, err = time.Parse(time.RFC3339Nano, max); err != nil {
+				return err
+			}
+		}
+		if !from.Before(to) {
+			return invalidArgument("min %v not before max %v", from, to)
+		}
+		spec.times = &[2]time.Time{from, to}
+		return nil
+	}
+	bits := leaf.Bits
+	switch leaf.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		size := bits()
+		lo, hi := int64(-1)<<(size-1), int64(1<<(size-1)-1)
+		parse := func(s string) (int64, error) {
+			if leaf == durationType {
+				d, err := time.ParseDuration(s)
+				return int64(d), err
+			}
+			return strconv.ParseInt(s, 0, size)
+		}
+		var err error
+		if min != "" {
+			if lo, err = parse(min); err != nil {
+				return err
+			}
+		}
+		if max != "" {
+			if hi, err = parse(max); err != nil {
+				return err
+			}
+		}
+		if lo > hi {
+			return invalidArgument("min %s > max %s", min, max)
+		}
+		spec.ints = &[2]int64{lo, hi}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		size := bits()
+		lo, hi := uint64(0), ^uint64(0)>>(64-size)
+		var err error
+		if min != "" {
+			if lo, err = strconv.ParseUint(min, 0, size); err != nil {
+				return err
+			}
+		}
+		if max != "" {
+			if hi, err = strconv.ParseUint(max, 0, size); err != nil {
+				return err
+			}
+		}
+		if lo > hi {
+			return invalidArgument("min %s > max %s", min, max)
+		}
+		spec.uints = &[2]uint64{lo, hi}
+	case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		lo, hi := 0.0, 1.0
+		var err error
+		if min != "" {
+			if lo, err = strconv.ParseFloat(min, 64); err != nil {
+				return err
+			}
+		}
+		if max != "" {
+			if hi, err = strconv.ParseFloat(max, 64); err != nil {
+				return err
+			}
+		}
+		if !(lo < hi) || math.IsInf(hi-lo, 0) {
+			return invalidArgument("min %s, max %s", min, max)
+		}
+		spec.floats = &[2]float64{lo, hi}
+	default:
+		return invalidArgument("min and max don't apply to %v", leaf)
+	}
+	return nil
+}
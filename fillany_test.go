@@ -0,0 +1,193 @@
+package fastrand64
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fillAddress struct {
+	Street string `fastrand:"len=12,chars=abcdef "`
+	Zip    string `fastrand:"len=5,chars=0123456789"`
+}
+
+type fillUser struct {
+	ID       uint64
+	Name     string  `fastrand:"minlen=3,maxlen=8,alphabet=hex"`
+	Age      int     `fastrand:"min=18,max=99"`
+	Score    float64 `fastrand:"min=-1,max=1"`
+	Ratio    float32
+	Admin    bool
+	Tags     []string      `fastrand:"len=3,alphabet=base62"`
+	Dice     []uint8       `fastrand:"len=50,min=1,max=6"`
+	Raw      []byte        `fastrand:"len=16"`
+	TTL      time.Duration `fastrand:"min=1s,max=1h"`
+	Created  time.Time     `fastrand:"min=2024-01-01T00:00:00Z,max=2024-02-01T00:00:00Z"`
+	Address  *fillAddress
+	Previous []fillAddress  `fastrand:"maxlen=2"`
+	Counts   map[string]int `fastrand:"len=2,min=0,max=9"`
+	Grid     [2][3]int8     `fastrand:"min=-1,max=1"`
+	Skipped  string         `fastrand:"-"`
+	Any      interface{}
+	hidden   int
+}
+
+func Test_Fill(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	for i := 0; i < 200; i++ {
+		u := fillUser{Skipped: "keep"}
+		assert.NoError(t, Fill(rng, &u))
+		assert.True(t, len(u.Name) >= 3 && len(u.Name) <= 8)
+		assert.Equal(t, "", strings.Trim(u.Name, "0123456789abcdef"))
+		assert.True(t, u.Age >= 18 && u.Age <= 99)
+		assert.True(t, u.Score >= -1 && u.Score < 1)
+		assert.True(t, u.Ratio >= 0 && u.Ratio < 1)
+		assert.Equal(t, 3, len(u.Tags))
+		for _, tag := range u.Tags {
+			assert.True(t, len(tag) >= 1 && len(tag) <= 16)
+		}
+		assert.Equal(t, 50, len(u.Dice))
+		for _, d := range u.Dice {
+			assert.True(t, d >= 1 && d <= 6)
+		}
+		assert.Equal(t, 16, len(u.Raw))
+		assert.True(t, u.TTL >= time.Second && u.TTL <= time.Hour)
+		assert.Equal(t, 2024, u.Created.Year())
+		assert.Equal(t, time.January, u.Created.Month())
+		assert.NotNil(t, u.Address)
+		assert.Equal(t, 12, len(u.Address.Street))
+		assert.Equal(t, 5, len(u.Address.Zip))
+		assert.True(t, len(u.Previous) >= 1 && len(u.Previous) <= 2)
+		assert.True(t, len(u.Counts) >= 1 && len(u.Counts) <= 2)
+		for _, c := range u.Counts {
+			assert.True(t, c >= 0 && c <= 9)
+		}
+		for _, row := range u.Grid {
+			for _, x := range row {
+				assert.True(t, x >= -1 && x <= 1)
+			}
+		}
+		assert.Equal(t, "keep", u.Skipped)
+		assert.Nil(t, u.Any)
+		assert.Equal(t, 0, u.hidden)
+	}
+}
+
+func Test_Fill_Uniform(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	var v struct {
+		X int8   `fastrand:"min=-2,max=1"`
+		Y uint64 `fastrand:"min=0,max=18446744073709551615"`
+	}
+	counts := map[int8]int{}
+	for i := 0; i < 40000; i++ {
+		assert.NoError(t, Fill(rng, &v))
+		counts[v.X]++
+	}
+	assert.Equal(t, 4, len(counts))
+	for _, n := range counts {
+		assert.InDelta(t, 10000, n, 600)
+	}
+}
+
+func Test_Fill_MapKeys(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	var v struct {
+		Small map[int8]int      `fastrand:"len=20,min=0,max=1000"`
+		Names map[string]string `fastrand:"len=3"`
+	}
+	keyLens := map[int]bool{}
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, Fill(rng, &v))
+		for _, x := range v.Small {
+			assert.True(t, x >= 0 && x <= 1000)
+		}
+		for k, x := range v.Names {
+			assert.Equal(t, 3, len(x))
+			keyLens[len(k)] = true
+		}
+	}
+	// keys aren't held to the element's length
+	assert.True(t, len(keyLens) > 1)
+}
+
+type fillNode struct {
+	Next *fillNode
+}
+
+func Test_Fill_Recursive(t *testing.T) {
+	var n fillNode
+	assert.NoError(t, Fill(NewUnsafeXoshiro256ssRNG(1), &n))
+	depth := 0
+	for p := n.Next; p != nil; p = p.Next {
+		depth++
+	}
+	assert.True(t, depth > 0 && depth < fillMaxDepth)
+}
+
+func Test_Fill_Scalars(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	var x int
+	var s []string
+	assert.NoError(t, Fill(rng, &x))
+	assert.NoError(t, Fill(rng, &s))
+	assert.True(t, len(s) >= 1)
+}
+
+func Test_Fill_Errors(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	var x int
+	for _, v := range []interface{}{nil, x, (*int)(nil)} {
+		assert.True(t, errors.Is(Fill(rng, v), ErrInvalidArgument))
+	}
+	for _, v := range []interface{}{
+		&struct {
+			A int `fastrand:"min=5,max=4"`
+		}{},
+		&struct {
+			A int8 `fastrand:"max=300"`
+		}{},
+		&struct {
+			A string `fastrand:"min=1"`
+		}{},
+		&struct {
+			A string `fastrand:"alphabet=klingon"`
+		}{},
+		&struct {
+			A string `fastrand:"minlen=5,maxlen=4"`
+		}{},
+		&struct {
+			A []int `fastrand:"len=-1"`
+		}{},
+		&struct {
+			A float64 `fastrand:"min=1,max=1"`
+		}{},
+		&struct {
+			A int `fastrand:"bogus"`
+		}{},
+		&struct {
+			A time.Time `fastrand:"min=yesterday"`
+		}{},
+	} {
+		assert.Error(t, Fill(rng, v), "%T", v)
+	}
+}
+
+func Test_SafeRNG_Fill(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	var u fillUser
+	assert.NoError(t, rng.Fill(&u))
+	assert.True(t, u.Age >= 18)
+}
+
+func Benchmark_Fill_Struct(b *testing.B) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	var u fillUser
+	for i := 0; i < b.N; i++ {
+		Fill(rng, &u)
+	}
+	BenchSink = &u
+}
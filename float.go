@@ -0,0 +1,75 @@
+package fastrand64
+
+import "math"
+
+// Float64 returns a pseudorandom float64 in the range [0.0, 1.0) from a thread unsafe RNG
+//
+// The top 53 bits of a Uint64 are used, so every representable value is equally likely
+func Float64(r UnsafeRNG) float64 {
+	return float64(r.Uint64()>>11) / (1 << 53)
+}
+
+// Float64OpenClosed returns a pseudorandom float64 in the range (0.0, 1.0] from a thread unsafe RNG
+//
+// Handy for log transforms, since it can never return 0
+func Float64OpenClosed(r UnsafeRNG) float64 {
+	return float64((r.Uint64()>>11)+1) / (1 << 53)
+}
+
+// Float64Open returns a pseudorandom float64 in the range (0.0, 1.0) from a thread unsafe RNG
+//
+// The result is the midpoint of one of 2^52 equal sized buckets, so it can never be 0 or 1
+func Float64Open(r UnsafeRNG) float64 {
+	return (float64(r.Uint64()>>12) + 0.5) / (1 << 52)
+}
+
+// Float64Range returns a pseudorandom float64 in the range [min, max) from a thread unsafe RNG
+//
+// It panics if min >= max, if either bound is NaN or infinite, or if max-min overflows
+func Float64Range(r UnsafeRNG, min, max float64) float64 {
+	width := max - min
+	if !(min < max) || math.IsInf(width, 0) {
+		panic("invalid argument to Float64Range")
+	}
+	for {
+		// min + width*u can round up to max when u is very close to 1, so just draw again
+		x := min + width*Float64(r)
+		if x < max {
+			return x
+		}
+	}
+}
+
+// Float64 returns a pseudorandom float64 in the range [0.0, 1.0). Threadsafe
+func (s *ThreadsafePoolRNG) Float64() float64 {
+	r, pool := s.get()
+	x := Float64(r)
+	pool.put(r)
+	return x
+}
+
+// Float64OpenClosed returns a pseudorandom float64 in the range (0.0, 1.0]. Threadsafe
+func (s *ThreadsafePoolRNG) Float64OpenClosed() float64 {
+	r, pool := s.get()
+	x := Float64OpenClosed(r)
+	pool.put(r)
+	return x
+}
+
+// Float64Open returns a pseudorandom float64 in the range (0.0, 1.0). Threadsafe
+func (s *ThreadsafePoolRNG) Float64Open() float64 {
+	r, pool := s.get()
+	x := Float64Open(r)
+	pool.put(r)
+	return x
+}
+
+// Float64Range returns a pseudorandom float64 in the range [min, max). Threadsafe
+//
+// It panics if min >= max, if either bound is NaN or infinite, or if max-min overflows
+func (s *ThreadsafePoolRNG) Float64Range(min, max float64) float64 {
+	r, pool := s.get()
+	x := Float64Range(r, min, max)
+	pool.put(r)
+	return x
+}
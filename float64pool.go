@@ -0,0 +1,87 @@
+package fastrand64
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// float64BatchSize is how many float64s a shard converts at once when its buffer runs dry
+const float64BatchSize = 256
+
+// ThreadsafeFloat64Pool is a pool backed source that only produces float64s in [0.0, 1.0), for Monte Carlo
+// kernels where converting per call is the bottleneck. Each pooled shard owns a generator and a ring buffer
+// that is refilled a batch at a time in a tight loop, so most calls are a pool checkout and a slice read
+type ThreadsafeFloat64Pool struct {
+	shardPool sync.Pool
+}
+
+type float64Shard struct {
+	r   UnsafeRNG
+	pos int
+	buf [float64BatchSize]float64
+}
+
+func (sh *float64Shard) refill() {
+	for i := range sh.buf {
+		sh.buf[i] = float64(sh.r.Uint64()>>11) / (1 << 53)
+	}
+	sh.pos = 0
+}
+
+// NewFloat64Pool wraps a sync.Pool of buffered shards around a thread unsafe RNG
+func NewFloat64Pool(fn func() UnsafeRNG) *ThreadsafeFloat64Pool {
+	p := &ThreadsafeFloat64Pool{}
+	p.shardPool = sync.Pool{New: func() interface{} {
+		return &float64Shard{r: fn(), pos: float64BatchSize}
+	}}
+	return p
+}
+
+// NewFloat64PoolXoshiro256ss conveniently allocates a float64 pool backed by xoshiro256** generators
+func NewFloat64PoolXoshiro256ss() *ThreadsafeFloat64Pool {
+	rand.Seed(time.Now().UnixNano())
+	return NewFloat64Pool(func() UnsafeRNG {
+		return NewUnsafeXoshiro256ssRNG(int64(rand.Uint64()))
+	})
+}
+
+// next returns the next buffered float64, refilling the buffer when it runs dry
+func (sh *float64Shard) next() float64 {
+	if sh.pos == float64BatchSize {
+		sh.refill()
+	}
+	x := sh.buf[sh.pos]
+	sh.pos++
+	return x
+}
+
+// fill drains what's buffered into dst first, then converts straight into the rest of it
+func (sh *float64Shard) fill(dst []float64) {
+	i := 0
+	for ; i < len(dst) && sh.pos < float64BatchSize; i++ {
+		dst[i] = sh.buf[sh.pos]
+		sh.pos++
+	}
+	for ; i < len(dst); i++ {
+		dst[i] = float64(sh.r.Uint64()>>11) / (1 << 53)
+	}
+}
+
+// Float64 returns a pseudorandom float64 in the range [0.0, 1.0). Threadsafe
+func (p *ThreadsafeFloat64Pool) Float64() float64 {
+	sh := p.shardPool.Get().(*float64Shard)
+	x := sh.next()
+	HandOff(sh.r)
+	p.shardPool.Put(sh)
+	return x
+}
+
+// Fill fills dst with pseudorandom float64s in the range [0.0, 1.0), checking a shard out once. Threadsafe
+func (p *ThreadsafeFloat64Pool) Fill(dst []float64) []float64 {
+	sh := p.shardPool.Get().(*float64Shard)
+	sh.fill(dst)
+	HandOff(sh.r)
+	p.shardPool.Put(sh)
+	return dst
+}
@@ -0,0 +1,70 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Float64Shard(t *testing.T) {
+	sh := &float64Shard{r: NewUnsafeRandRNG(1), pos: float64BatchSize}
+	expected := NewUnsafeRandRNG(1)
+	for i := 0; i < 3*float64BatchSize+10; i++ {
+		assert.Equal(t, Float64(expected), sh.next())
+	}
+	// fill drains the rest of the buffer, then converts directly
+	dst := make([]float64, 1000)
+	sh.fill(dst)
+	for _, x := range dst {
+		assert.Equal(t, Float64(expected), x)
+	}
+}
+
+func Test_Float64Pool_Float64(t *testing.T) {
+	// sync.Pool may drop a shard at any time (it does so on purpose under -race), and its replacement starts
+	// the stream over, so only check values come from the stream
+	p := NewFloat64Pool(func() UnsafeRNG { return NewUnsafeRandRNG(1) })
+	expected := NewUnsafeRandRNG(1)
+	stream := map[float64]bool{}
+	for i := 0; i < 3*float64BatchSize; i++ {
+		stream[Float64(expected)] = true
+	}
+	for i := 0; i < 3*float64BatchSize; i++ {
+		assert.True(t, stream[p.Float64()])
+	}
+}
+
+func Test_Float64Pool_Fill(t *testing.T) {
+	p := NewFloat64Pool(func() UnsafeRNG { return NewUnsafeRandRNG(1) })
+	expected := NewUnsafeRandRNG(1)
+	// a new pool's first shard is new too
+	dst := p.Fill(make([]float64, 1000))
+	for _, x := range dst {
+		assert.Equal(t, Float64(expected), x)
+	}
+
+	mean, _ := sampleMoments(100000, NewFloat64PoolXoshiro256ss().Float64)
+	assert.InDelta(t, 0.5, mean, 0.01)
+}
+
+func Benchmark_Float64Pool_Float64_Parallel(b *testing.B) {
+	p := NewFloat64PoolXoshiro256ss()
+	b.RunParallel(func(pb *testing.PB) {
+		r := p.Float64()
+		for pb.Next() {
+			r = p.Float64()
+		}
+		BenchSink = &r
+	})
+}
+
+func Benchmark_SyncPoolXoshiro256ssRNG_Float64_Parallel(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rng.Float64()
+		for pb.Next() {
+			r = rng.Float64()
+		}
+		BenchSink = &r
+	})
+}
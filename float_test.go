@@ -0,0 +1,53 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type constRNG uint64
+
+func (c constRNG) Uint64() uint64 { return uint64(c) }
+
+func Test_Float64_Bounds(t *testing.T) {
+	assert.Equal(t, 0.0, Float64(constRNG(0)))
+	assert.Less(t, Float64(constRNG(math.MaxUint64)), 1.0)
+
+	assert.Greater(t, Float64OpenClosed(constRNG(0)), 0.0)
+	assert.Equal(t, 1.0, Float64OpenClosed(constRNG(math.MaxUint64)))
+
+	assert.Greater(t, Float64Open(constRNG(0)), 0.0)
+	assert.Less(t, Float64Open(constRNG(math.MaxUint64)), 1.0)
+}
+
+func Test_SafeRNG_Float64(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for i := 0; i < 4096; i++ {
+		x := rng.Float64()
+		assert.GreaterOrEqual(t, x, 0.0)
+		assert.Less(t, x, 1.0)
+
+		x = rng.Float64OpenClosed()
+		assert.Greater(t, x, 0.0)
+		assert.LessOrEqual(t, x, 1.0)
+
+		x = rng.Float64Open()
+		assert.Greater(t, x, 0.0)
+		assert.Less(t, x, 1.0)
+	}
+}
+
+func Test_SafeRNG_Float64Range(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for i := 0; i < 4096; i++ {
+		x := rng.Float64Range(-3, 5)
+		assert.GreaterOrEqual(t, x, -3.0)
+		assert.Less(t, x, 5.0)
+	}
+	assert.Panics(t, func() { rng.Float64Range(1, 1) })
+	assert.Panics(t, func() { rng.Float64Range(2, 1) })
+	assert.Panics(t, func() { rng.Float64Range(math.NaN(), 1) })
+	assert.Panics(t, func() { rng.Float64Range(-math.MaxFloat64, math.MaxFloat64) })
+}
@@ -0,0 +1,140 @@
+// Package gendata generates slices with a controlled shape, sorted, nearly sorted, full of duplicates or Zipf
+// skewed, on top of fastrand64, for benchmarking sorts, indexes and compressors against more than uniform noise.
+//
+// Every function takes any fastrand64.UnsafeRNG as its source, which is not safe to share between goroutines.
+// Wrap a ThreadsafePoolRNG in a Generator to get thread safe versions.
+//
+// Example:
+//
+//	rng := fastrand64.NewSyncPoolXoshiro256ssRNG()
+//	g := gendata.NewGenerator(rng)
+//
+//	// somewhere later, in a benchmark
+//	data := g.KSorted(1<<20, 16)
+//	keys, err := g.Strings(1<<20, 12, []string{"user:", "order:"}, []float64{9, 1})
+package gendata
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+// MaxValue bounds the values of the int generators, they are uniform in [0, MaxValue] so they behave the same
+// where int is 32 bits
+const MaxValue = math.MaxInt32
+
+// Ints returns n ints drawn uniformly from [0, MaxValue]. It panics if n < 0
+func Ints(r fastrand64.UnsafeRNG, n int) []int {
+	if n < 0 {
+		panic("invalid argument to Ints")
+	}
+	s := make([]int, n)
+	for i := range s {
+		s[i] = int(r.Uint64() >> 33)
+	}
+	return s
+}
+
+// Sorted returns n random ints in ascending order. It panics if n < 0
+func Sorted(r fastrand64.UnsafeRNG, n int) []int {
+	s := Ints(r, n)
+	slices.Sort(s)
+	return s
+}
+
+// ReverseSorted returns n random ints in descending order. It panics if n < 0
+func ReverseSorted(r fastrand64.UnsafeRNG, n int) []int {
+	s := Sorted(r, n)
+	slices.Reverse(s)
+	return s
+}
+
+// KSorted returns n random ints that are nearly sorted: each is at most k positions away from where it
+// belongs. k = 0 gives sorted data, and k >= n is close to uniform noise. It panics if n or k is negative
+func KSorted(r fastrand64.UnsafeRNG, n, k int) []int {
+	if k < 0 {
+		panic("invalid argument to KSorted")
+	}
+	s := Sorted(r, n)
+	// position i sorts by i plus a jitter below k+1, so only values less than k+1 apart can change order
+	type keyed struct {
+		key   float64
+		value int
+	}
+	ks := make([]keyed, n)
+	for i, v := range s {
+		ks[i] = keyed{float64(i) + fastrand64.Float64(r)*float64(k+1), v}
+	}
+	slices.SortFunc(ks, func(a, b keyed) int {
+		switch {
+		case a.key < b.key:
+			return -1
+		case a.key > b.key:
+			return 1
+		}
+		return 0
+	})
+	for i := range ks {
+		s[i] = ks[i].value
+	}
+	return s
+}
+
+// FewUnique returns n ints in random order that take only unique distinct values, for testing how code copes
+// with heavy duplication. It panics if n < 0 or unique < 1
+func FewUnique(r fastrand64.UnsafeRNG, n, unique int) []int {
+	if n < 0 || unique < 1 {
+		panic("invalid argument to FewUnique")
+	}
+	values := Ints(r, unique)
+	s := make([]int, n)
+	for i := range s {
+		s[i] = values[fastrand64.Intn(r, unique)]
+	}
+	return s
+}
+
+// Zipf returns n ints in [0, imax] with P(k) proportional to (1 + k) ** (-skew), so small values dominate
+// the way popular keys do in real workloads. skew must be > 1, see fastrand64.NewZipf. It panics if n < 0
+func Zipf(r fastrand64.UnsafeRNG, n int, skew float64, imax int) ([]int, error) {
+	if n < 0 || imax < 0 {
+		panic("invalid argument to Zipf")
+	}
+	z, err := fastrand64.NewZipf(r, skew, 1, uint64(imax))
+	if err != nil {
+		return nil, err
+	}
+	s := make([]int, n)
+	for i := range s {
+		s[i] = int(z.Uint64())
+	}
+	return s, nil
+}
+
+// Strings returns n strings, each one of prefixes chosen with probability proportional to its weight, followed
+// by suffixLen random base62 characters. An error is returned if the lengths of prefixes and weights differ or
+// the weights can't be sampled from. It panics if n or suffixLen is negative
+func Strings(r fastrand64.UnsafeRNG, n, suffixLen int, prefixes []string, weights []float64) ([]string, error) {
+	if n < 0 || suffixLen < 0 {
+		panic("invalid argument to Strings")
+	}
+	if len(prefixes) != len(weights) {
+		return nil, fmt.Errorf("%w: Strings: %d prefixes but %d weights", fastrand64.ErrInvalidArgument,
+			len(prefixes), len(weights))
+	}
+	pick, err := fastrand64.NewWeightedSampler(r, weights)
+	if err != nil {
+		return nil, err
+	}
+	s := make([]string, n)
+	var buf []byte
+	for i := range s {
+		buf = append(buf[:0], prefixes[pick.Next()]...)
+		buf = fastrand64.AlphabetBase62.Append(r, buf, suffixLen)
+		s[i] = string(buf)
+	}
+	return s, nil
+}
@@ -0,0 +1,126 @@
+package gendata
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+func Test_Ints(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	s := Ints(rng, 10000)
+	assert.Equal(t, 10000, len(s))
+	for _, v := range s {
+		assert.True(t, v >= 0 && v <= MaxValue)
+	}
+	assert.False(t, slices.IsSorted(s))
+	assert.Equal(t, []int{}, Ints(rng, 0))
+	assert.Panics(t, func() { Ints(rng, -1) })
+}
+
+func Test_Sorted(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	assert.True(t, slices.IsSorted(Sorted(rng, 1000)))
+	r := ReverseSorted(rng, 1000)
+	slices.Reverse(r)
+	assert.True(t, slices.IsSorted(r))
+}
+
+func Test_KSorted(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	for _, k := range []int{0, 1, 5, 100} {
+		s := KSorted(rng, 10000, k)
+		sorted := slices.Clone(s)
+		slices.Sort(sorted)
+		displaced := 0
+		for i, v := range s {
+			j, _ := slices.BinarySearch(sorted, v)
+			assert.True(t, i-j <= k && j-i <= k, "k %d: %d moved to %d", k, j, i)
+			if i != j {
+				displaced++
+			}
+		}
+		if k == 0 {
+			assert.Equal(t, 0, displaced)
+		} else {
+			assert.True(t, displaced > 1000, "k %d", k)
+		}
+	}
+	assert.Panics(t, func() { KSorted(rng, 10, -1) })
+}
+
+func Test_FewUnique(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	s := FewUnique(rng, 10000, 4)
+	counts := map[int]int{}
+	for _, v := range s {
+		counts[v]++
+	}
+	assert.Equal(t, 4, len(counts))
+	for _, c := range counts {
+		assert.InDelta(t, 2500, c, 6*math.Sqrt(1875))
+	}
+	assert.Panics(t, func() { FewUnique(rng, 10, 0) })
+	assert.Panics(t, func() { FewUnique(rng, -1, 1) })
+}
+
+func Test_Zipf(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	s, err := Zipf(rng, 10000, 1.5, 1000)
+	assert.NoError(t, err)
+	counts := make([]int, 1001)
+	for _, v := range s {
+		counts[v]++
+	}
+	assert.True(t, counts[0] > counts[1] && counts[1] > counts[10] && counts[10] > counts[1000])
+
+	_, err = Zipf(rng, 10, 1, 1000)
+	assert.True(t, errors.Is(err, fastrand64.ErrInvalidArgument))
+	assert.Panics(t, func() { Zipf(rng, -1, 1.5, 10) })
+}
+
+func Test_Strings(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	s, err := Strings(rng, 10000, 8, []string{"user:", "order:", "x"}, []float64{3, 1, 0})
+	assert.NoError(t, err)
+	users := 0
+	for _, v := range s {
+		switch {
+		case strings.HasPrefix(v, "user:"):
+			users++
+			assert.Equal(t, 13, len(v))
+		case strings.HasPrefix(v, "order:"):
+			assert.Equal(t, 14, len(v))
+		default:
+			t.Fatalf("unexpected prefix %q", v)
+		}
+	}
+	assert.InDelta(t, 7500, users, 6*math.Sqrt(1875))
+	assert.NotEqual(t, s[0], s[1])
+
+	_, err = Strings(rng, 10, 8, []string{"a"}, []float64{1, 1})
+	assert.True(t, errors.Is(err, fastrand64.ErrInvalidArgument))
+	_, err = Strings(rng, 10, 8, []string{"a"}, []float64{-1})
+	assert.True(t, errors.Is(err, fastrand64.ErrInvalidArgument))
+	assert.Panics(t, func() { Strings(rng, 10, -1, []string{"a"}, []float64{1}) })
+}
+
+func Test_Generator(t *testing.T) {
+	g := NewGenerator(fastrand64.NewSyncPoolXoshiro256ssRNG())
+	assert.Equal(t, 10, len(g.Ints(10)))
+	assert.True(t, slices.IsSorted(g.Sorted(100)))
+	assert.Equal(t, 100, len(g.ReverseSorted(100)))
+	assert.Equal(t, 100, len(g.KSorted(100, 3)))
+	assert.Equal(t, 100, len(g.FewUnique(100, 3)))
+	z, err := g.Zipf(100, 2, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, len(z))
+	s, err := g.Strings(100, 4, []string{"k"}, []float64{1})
+	assert.NoError(t, err)
+	assert.Equal(t, 100, len(s))
+}
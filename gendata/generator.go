@@ -0,0 +1,65 @@
+package gendata
+
+import fastrand64 "github.com/villenny/fastrand64-go"
+
+// Generator provides thread safe versions of the functions in this package, each slice checks a generator out
+// of the pool once
+type Generator struct {
+	rng *fastrand64.ThreadsafePoolRNG
+}
+
+// NewGenerator wraps a thread safe pool backed RNG
+func NewGenerator(rng *fastrand64.ThreadsafePoolRNG) *Generator {
+	return &Generator{rng: rng}
+}
+
+// Ints returns n ints drawn uniformly from [0, MaxValue]. Threadsafe
+func (g *Generator) Ints(n int) []int {
+	var s []int
+	g.rng.With(func(r fastrand64.UnsafeRNG) { s = Ints(r, n) })
+	return s
+}
+
+// Sorted returns n random ints in ascending order. Threadsafe
+func (g *Generator) Sorted(n int) []int {
+	var s []int
+	g.rng.With(func(r fastrand64.UnsafeRNG) { s = Sorted(r, n) })
+	return s
+}
+
+// ReverseSorted returns n random ints in descending order. Threadsafe
+func (g *Generator) ReverseSorted(n int) []int {
+	var s []int
+	g.rng.With(func(r fastrand64.UnsafeRNG) { s = ReverseSorted(r, n) })
+	return s
+}
+
+// KSorted returns n random ints each at most k positions from where it belongs. Threadsafe
+func (g *Generator) KSorted(n, k int) []int {
+	var s []int
+	g.rng.With(func(r fastrand64.UnsafeRNG) { s = KSorted(r, n, k) })
+	return s
+}
+
+// FewUnique returns n ints taking only unique distinct values. Threadsafe
+func (g *Generator) FewUnique(n, unique int) []int {
+	var s []int
+	g.rng.With(func(r fastrand64.UnsafeRNG) { s = FewUnique(r, n, unique) })
+	return s
+}
+
+// Zipf returns n Zipf distributed ints in [0, imax]. Threadsafe
+func (g *Generator) Zipf(n int, skew float64, imax int) ([]int, error) {
+	var s []int
+	var err error
+	g.rng.With(func(r fastrand64.UnsafeRNG) { s, err = Zipf(r, n, skew, imax) })
+	return s, err
+}
+
+// Strings returns n strings with weighted prefixes and random suffixes. Threadsafe
+func (g *Generator) Strings(n, suffixLen int, prefixes []string, weights []float64) ([]string, error) {
+	var s []string
+	var err error
+	g.rng.With(func(r fastrand64.UnsafeRNG) { s, err = Strings(r, n, suffixLen, prefixes, weights) })
+	return s, err
+}
@@ -0,0 +1,33 @@
+package fastrand64
+
+import "math"
+
+// Geometric returns the number of failures before the first success in independent trials that each succeed
+// with probability p, from a thread unsafe RNG. The result is 0, 1, 2... with mean (1-p)/p
+//
+// It panics if p is not in (0, 1]
+func Geometric(r UnsafeRNG, p float64) int {
+	if !(p > 0 && p <= 1) {
+		panic("invalid argument to Geometric")
+	}
+	if p == 1 {
+		return 0
+	}
+	// inversion, Float64OpenClosed keeps log away from zero
+	x := math.Floor(math.Log(Float64OpenClosed(r)) / math.Log1p(-p))
+	if x >= math.MaxInt {
+		return math.MaxInt
+	}
+	return int(x)
+}
+
+// Geometric returns the number of failures before the first success in independent trials that each succeed
+// with probability p. Threadsafe
+//
+// It panics if p is not in (0, 1]
+func (s *ThreadsafePoolRNG) Geometric(p float64) int {
+	r, pool := s.get()
+	x := Geometric(r, p)
+	pool.put(r)
+	return x
+}
@@ -0,0 +1,128 @@
+package fastrand64
+
+import "math"
+
+// UnitVector2 returns a uniformly random direction in the plane, a point on the unit circle, from a thread unsafe
+// RNG. It uses von Neumann's trick of squaring a random point in the unit disk, so there's no trig and no sqrt
+func UnitVector2(r UnsafeRNG) [2]float64 {
+	for {
+		x := 2*Float64(r) - 1
+		y := 2*Float64(r) - 1
+		s := x*x + y*y
+		if s < 1 && s > 0 {
+			return [2]float64{(x*x - y*y) / s, 2 * x * y / s}
+		}
+	}
+}
+
+// UnitVector3 returns a uniformly random direction in space, a point on the unit sphere, from a thread unsafe RNG.
+// It uses Marsaglia's method, "Choosing a point from the surface of a sphere" (1972)
+func UnitVector3(r UnsafeRNG) [3]float64 {
+	for {
+		x := 2*Float64(r) - 1
+		y := 2*Float64(r) - 1
+		s := x*x + y*y
+		if s < 1 {
+			f := 2 * math.Sqrt(1-s)
+			return [3]float64{x * f, y * f, 1 - 2*s}
+		}
+	}
+}
+
+// InDisk returns a uniformly random point inside the unit disk from a thread unsafe RNG. Rejecting points of the
+// square that fall outside the disk is faster than transforming polar coordinates, and unlike picking a random
+// radius it doesn't crowd the center
+func InDisk(r UnsafeRNG) [2]float64 {
+	for {
+		x := 2*Float64(r) - 1
+		y := 2*Float64(r) - 1
+		if x*x+y*y < 1 {
+			return [2]float64{x, y}
+		}
+	}
+}
+
+// OnSphere fills dst with a uniformly random point on the unit sphere of len(dst) dimensions from a thread unsafe
+// RNG, and returns it. It normalizes a vector of normal deviates, which works in any number of dimensions,
+// for 2 or 3 UnitVector2 and UnitVector3 are faster. It panics if dst is empty
+func OnSphere(r UnsafeRNG, dst []float64) []float64 {
+	if len(dst) == 0 {
+		panic("invalid argument to OnSphere")
+	}
+	for {
+		sum := 0.0
+		for i := range dst {
+			dst[i] = NormFloat64(r)
+			sum += dst[i] * dst[i]
+		}
+		// all zeros is vanishingly unlikely, but has no direction
+		if sum > 0 {
+			scale := 1 / math.Sqrt(sum)
+			for i := range dst {
+				dst[i] *= scale
+			}
+			return dst
+		}
+	}
+}
+
+// InBall fills dst with a uniformly random point inside the unit ball of len(dst) dimensions from a thread unsafe
+// RNG, and returns it. The radius is u^(1/n), so points are spread evenly by volume rather than crowded into
+// the center. It panics if dst is empty
+func InBall(r UnsafeRNG, dst []float64) []float64 {
+	if len(dst) == 0 {
+		panic("invalid argument to InBall")
+	}
+	OnSphere(r, dst)
+	radius := math.Pow(Float64(r), 1/float64(len(dst)))
+	for i := range dst {
+		dst[i] *= radius
+	}
+	return dst
+}
+
+// UnitVector2 returns a uniformly random point on the unit circle. Threadsafe
+func (s *ThreadsafePoolRNG) UnitVector2() [2]float64 {
+	r, pool := s.get()
+	v := UnitVector2(r)
+	pool.put(r)
+	return v
+}
+
+// UnitVector3 returns a uniformly random point on the unit sphere. Threadsafe
+func (s *ThreadsafePoolRNG) UnitVector3() [3]float64 {
+	r, pool := s.get()
+	v := UnitVector3(r)
+	pool.put(r)
+	return v
+}
+
+// InDisk returns a uniformly random point inside the unit disk. Threadsafe
+func (s *ThreadsafePoolRNG) InDisk() [2]float64 {
+	r, pool := s.get()
+	v := InDisk(r)
+	pool.put(r)
+	return v
+}
+
+// OnSphere fills dst with a uniformly random point on the unit sphere of len(dst) dimensions, and returns it.
+// Threadsafe
+//
+// It panics if dst is empty
+func (s *ThreadsafePoolRNG) OnSphere(dst []float64) []float64 {
+	r, pool := s.get()
+	OnSphere(r, dst)
+	pool.put(r)
+	return dst
+}
+
+// InBall fills dst with a uniformly random point inside the unit ball of len(dst) dimensions, and returns it.
+// Threadsafe
+//
+// It panics if dst is empty
+func (s *ThreadsafePoolRNG) InBall(dst []float64) []float64 {
+	r, pool := s.get()
+	InBall(r, dst)
+	pool.put(r)
+	return dst
+}
@@ -0,0 +1,125 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const geometrySamples = 80000
+
+// assertEvenOctants checks that the samples are spread evenly over the buckets of counts
+func assertEvenOctants(t *testing.T, counts []int, name string) {
+	want := float64(geometrySamples) / float64(len(counts))
+	for i, c := range counts {
+		assert.InDelta(t, want, c, 6*math.Sqrt(want), "%s octant %d", name, i)
+	}
+}
+
+func octant(v []float64) int {
+	o := 0
+	for i, x := range v {
+		if x < 0 {
+			o |= 1 << i
+		}
+	}
+	return o
+}
+
+func Test_UnitVector2(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	// uniform angles fall evenly in 8 sectors
+	sectors := make([]int, 8)
+	for i := 0; i < geometrySamples; i++ {
+		v := UnitVector2(rng)
+		assert.InDelta(t, 1, math.Hypot(v[0], v[1]), 1e-12)
+		a := math.Atan2(v[1], v[0]) + math.Pi
+		sectors[int(a/(2*math.Pi)*8)%8]++
+	}
+	assertEvenOctants(t, sectors, "UnitVector2")
+}
+
+func Test_UnitVector3(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	octants := make([]int, 8)
+	// on a uniform sphere z is uniform in [-1, 1] (Archimedes' hat box theorem)
+	zBands := make([]int, 4)
+	for i := 0; i < geometrySamples; i++ {
+		v := UnitVector3(rng)
+		assert.InDelta(t, 1, math.Sqrt(v[0]*v[0]+v[1]*v[1]+v[2]*v[2]), 1e-12)
+		octants[octant(v[:])]++
+		zBands[int((v[2]+1)/2*4)%4]++
+	}
+	assertEvenOctants(t, octants, "UnitVector3")
+	assertEvenOctants(t, zBands, "UnitVector3 z")
+}
+
+func Test_InDisk(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	inner := 0
+	for i := 0; i < geometrySamples; i++ {
+		v := InDisk(rng)
+		d := math.Hypot(v[0], v[1])
+		assert.True(t, d < 1)
+		// uniform by area, so half the points are within 1/sqrt(2)
+		if d < math.Sqrt2/2 {
+			inner++
+		}
+	}
+	assert.InDelta(t, geometrySamples/2, inner, 6*math.Sqrt(geometrySamples/4))
+}
+
+func Test_OnSphere_InBall(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	for _, n := range []int{1, 2, 3, 5} {
+		octants := make([]int, 1<<n)
+		inner := 0
+		v := make([]float64, n)
+		for i := 0; i < geometrySamples; i++ {
+			OnSphere(rng, v)
+			norm := 0.0
+			for _, x := range v {
+				norm += x * x
+			}
+			assert.InDelta(t, 1, norm, 1e-12)
+
+			InBall(rng, v)
+			norm = 0.0
+			for _, x := range v {
+				norm += x * x
+			}
+			assert.True(t, norm < 1)
+			octants[octant(v)]++
+			// uniform by volume, so half the points are within 0.5^(1/n)
+			if math.Sqrt(norm) < math.Pow(0.5, 1/float64(n)) {
+				inner++
+			}
+		}
+		assertEvenOctants(t, octants, "InBall")
+		assert.InDelta(t, geometrySamples/2, inner, 6*math.Sqrt(geometrySamples/4), "n=%d", n)
+	}
+	assert.Panics(t, func() { OnSphere(rng, nil) })
+	assert.Panics(t, func() { InBall(rng, []float64{}) })
+}
+
+func Test_SafeRNG_Geometry(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	v2 := rng.UnitVector2()
+	assert.InDelta(t, 1, math.Hypot(v2[0], v2[1]), 1e-12)
+	v3 := rng.UnitVector3()
+	assert.InDelta(t, 1, v3[0]*v3[0]+v3[1]*v3[1]+v3[2]*v3[2], 1e-12)
+	d := rng.InDisk()
+	assert.True(t, math.Hypot(d[0], d[1]) < 1)
+	assert.Equal(t, 4, len(rng.OnSphere(make([]float64, 4))))
+	assert.Equal(t, 4, len(rng.InBall(make([]float64, 4))))
+}
+
+func Benchmark_UnitVector3(b *testing.B) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	var v [3]float64
+	for i := 0; i < b.N; i++ {
+		v = UnitVector3(rng)
+	}
+	BenchSink = &v
+}
@@ -1,6 +1,6 @@
 module github.com/villenny/fastrand64-go
 
-go 1.14
+go 1.24
 
 require (
 	github.com/stretchr/testify v1.5.1
@@ -8,3 +8,9 @@ require (
 	github.com/valyala/fastrand v1.0.0
 	github.com/yalue/native_endian v0.0.0-20180607135909-51013b03be4f
 )
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v2 v2.2.2 // indirect
+)
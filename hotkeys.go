@@ -0,0 +1,143 @@
+package fastrand64
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// HotKeyConfig describes the skew of a HotKeys workload
+type HotKeyConfig struct {
+	// Keys is the size of the key space, keys are drawn from [0..Keys)
+	Keys uint64
+	// HotKeys is the size of the hot set, it must be at least 1 and less than Keys
+	HotKeys int
+	// HotShare is the fraction of draws that hit the hot set, in [0, 1]. The rest are uniform over the cold keys
+	HotShare float64
+	// Exponent is the Zipf exponent of popularity within the hot set, the key of rank i is drawn in
+	// proportion to 1/(i+1)^Exponent. 0 makes the hot set uniform
+	Exponent float64
+	// Churn is the fraction of the hot set replaced by fresh keys on each Tick, in [0, 1]
+	Churn float64
+}
+
+// HotKeys generates integer keys for cache and database benchmarks, where a small hot set takes a set share of
+// the traffic and slowly changes over time, instead of the static skew of a plain Zipf.
+//
+// Tick swaps in a new hot set atomically, so Next never locks. Backed by a ThreadsafePoolRNG it is safe to
+// use from concurrent goroutines, backed by anything else it is only as safe as its source
+type HotKeys struct {
+	r         UnsafeRNG
+	cfg       HotKeyConfig
+	ranks     *aliasTable
+	threshold uint64 // draws with their top 53 bits below this go to the hot set
+	hot       atomic.Pointer[hotSet]
+	mu        sync.Mutex
+	debt      float64 // fractional keys of churn carried over to the next Tick
+}
+
+type hotSet struct {
+	keys   []uint64 // by rank, hottest first
+	member map[uint64]struct{}
+}
+
+// NewHotKeys creates a HotKeys generator drawing from r, with a random initial hot set
+func NewHotKeys(r UnsafeRNG, cfg HotKeyConfig) (*HotKeys, error) {
+	if r == nil {
+		return nil, invalidArgument("NewHotKeys: nil source")
+	}
+	if cfg.HotKeys < 1 || uint64(cfg.HotKeys) >= cfg.Keys {
+		return nil, invalidArgument("NewHotKeys: need 1 <= HotKeys < Keys, got %d and %d", cfg.HotKeys, cfg.Keys)
+	}
+	if !(cfg.HotShare >= 0 && cfg.HotShare <= 1) {
+		return nil, invalidArgument("NewHotKeys: HotShare %v not in [0, 1]", cfg.HotShare)
+	}
+	if !(cfg.Exponent >= 0) || math.IsInf(cfg.Exponent, 0) {
+		return nil, invalidArgument("NewHotKeys: Exponent %v", cfg.Exponent)
+	}
+	if !(cfg.Churn >= 0 && cfg.Churn <= 1) {
+		return nil, invalidArgument("NewHotKeys: Churn %v not in [0, 1]", cfg.Churn)
+	}
+	weights := make([]float64, cfg.HotKeys)
+	for i := range weights {
+		weights[i] = math.Pow(float64(i+1), -cfg.Exponent)
+	}
+	ranks, err := newAliasTable("NewHotKeys", weights)
+	if err != nil {
+		return nil, err
+	}
+	h := &HotKeys{r: r, cfg: cfg, ranks: ranks, threshold: uint64(cfg.HotShare * (1 << 53))}
+	set := &hotSet{keys: make([]uint64, cfg.HotKeys), member: make(map[uint64]struct{}, cfg.HotKeys)}
+	for i := range set.keys {
+		k := set.coldKey(r, cfg.Keys)
+		set.keys[i] = k
+		set.member[k] = struct{}{}
+	}
+	h.hot.Store(set)
+	return h, nil
+}
+
+// coldKey draws uniformly from the keys outside the set, by rejection, which takes Keys/(Keys-HotKeys) tries
+// on average
+func (s *hotSet) coldKey(r UnsafeRNG, keys uint64) uint64 {
+	for {
+		k := Uint64n(r, keys)
+		if _, ok := s.member[k]; !ok {
+			return k
+		}
+	}
+}
+
+// Next returns the next key
+func (h *HotKeys) Next() uint64 {
+	set := h.hot.Load()
+	if p, ok := h.r.(*ThreadsafePoolRNG); ok {
+		r, pool := p.get()
+		k := h.next(r, set)
+		pool.put(r)
+		return k
+	}
+	return h.next(h.r, set)
+}
+
+func (h *HotKeys) next(r UnsafeRNG, set *hotSet) uint64 {
+	if r.Uint64()>>11 < h.threshold {
+		return set.keys[h.ranks.next(r)]
+	}
+	return set.coldKey(r, h.cfg.Keys)
+}
+
+// Tick advances the workload one step in time, replacing Churn of the hot set with keys that were cold.
+// The replaced ranks are chosen at random, and the new keys take over their popularity
+func (h *HotKeys) Tick() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.debt += h.cfg.Churn * float64(h.cfg.HotKeys)
+	n := int(h.debt)
+	h.debt -= float64(n)
+	if n == 0 {
+		return
+	}
+	old := h.hot.Load()
+	set := &hotSet{keys: make([]uint64, len(old.keys)), member: make(map[uint64]struct{}, len(old.member))}
+	copy(set.keys, old.keys)
+	for k := range old.member {
+		set.member[k] = struct{}{}
+	}
+	for _, rank := range SampleInts(h.r, len(set.keys), n) {
+		// drawn before the old key leaves, so a replaced key never comes straight back
+		k := set.coldKey(h.r, h.cfg.Keys)
+		delete(set.member, set.keys[rank])
+		set.keys[rank] = k
+		set.member[k] = struct{}{}
+	}
+	h.hot.Store(set)
+}
+
+// HotSet returns a copy of the current hot set, hottest first
+func (h *HotKeys) HotSet() []uint64 {
+	keys := h.hot.Load().keys
+	result := make([]uint64, len(keys))
+	copy(result, keys)
+	return result
+}
@@ -0,0 +1,91 @@
+package fastrand64
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewHotKeys_Errors(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	good := HotKeyConfig{Keys: 1000, HotKeys: 10, HotShare: 0.9, Exponent: 1, Churn: 0.1}
+	for _, mod := range []func(c *HotKeyConfig){
+		func(c *HotKeyConfig) { c.HotKeys = 0 },
+		func(c *HotKeyConfig) { c.HotKeys = 1000 },
+		func(c *HotKeyConfig) { c.HotShare = 1.5 },
+		func(c *HotKeyConfig) { c.Exponent = -1 },
+		func(c *HotKeyConfig) { c.Churn = -0.1 },
+	} {
+		cfg := good
+		mod(&cfg)
+		h, err := NewHotKeys(r, cfg)
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+		assert.Nil(t, h)
+	}
+	_, err := NewHotKeys(nil, good)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+func Test_HotKeys_Profile(t *testing.T) {
+	h, err := NewHotKeys(NewSyncPoolXoshiro256ssRNG(), HotKeyConfig{Keys: 1 << 20, HotKeys: 4, HotShare: 0.8, Exponent: 1})
+	assert.NoError(t, err)
+	hot := h.HotSet()
+	assert.Equal(t, 4, len(hot))
+
+	counts := map[uint64]int{}
+	const n = 100000
+	for i := 0; i < n; i++ {
+		counts[h.Next()]++
+	}
+	// weights 1, 1/2, 1/3, 1/4 share 80% of the traffic
+	total := 0
+	for i, k := range hot {
+		expected := 0.8 * n * (1 / float64(i+1)) / (1 + 1.0/2 + 1.0/3 + 1.0/4)
+		assert.InDelta(t, expected, counts[k], 0.05*expected)
+		total += counts[k]
+	}
+	assert.InDelta(t, 0.8*n, total, 0.01*n)
+}
+
+func Test_HotKeys_Churn(t *testing.T) {
+	h, err := NewHotKeys(NewUnsafeXoshiro256ssRNG(1), HotKeyConfig{Keys: 1000, HotKeys: 10, HotShare: 1, Churn: 0.25})
+	assert.NoError(t, err)
+	before := h.HotSet()
+	h.Tick()
+	after := h.HotSet()
+	changed := 0
+	for i := range before {
+		if before[i] != after[i] {
+			changed++
+		}
+	}
+	// 2.5 keys per tick, so 2 now and the half carried over
+	assert.Equal(t, 2, changed)
+	h.Tick()
+	changed = 0
+	for i, k := range h.HotSet() {
+		if k != after[i] {
+			changed++
+		}
+	}
+	assert.Equal(t, 3, changed)
+
+	seen := map[uint64]bool{}
+	for _, k := range h.HotSet() {
+		assert.False(t, seen[k])
+		seen[k] = true
+	}
+	for i := 0; i < 1000; i++ {
+		assert.True(t, seen[h.Next()])
+	}
+}
+
+func Benchmark_HotKeys_Next(b *testing.B) {
+	h, _ := NewHotKeys(NewSyncPoolXoshiro256ssRNG(), HotKeyConfig{Keys: 1 << 30, HotKeys: 1000, HotShare: 0.9, Exponent: 0.99, Churn: 0.01})
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r += h.Next()
+	}
+	BenchSink = &r
+}
@@ -0,0 +1,82 @@
+package fastrand64
+
+import "encoding/binary"
+
+const (
+	// rdrandRetries follows Intel's advice, RDRAND only fails this many times in a row if the cpu is broken
+	rdrandRetries = 10
+	// rdseedRetries is higher, RDSEED runs dry under load and needs time to gather more entropy
+	rdseedRetries = 100
+)
+
+// UnsafeHardwareRNG draws from the cpu's RDRAND or RDSEED instruction on amd64, for hardware entropy without
+// the syscall behind crypto/rand. On cpus without the instruction, or if it stops answering, it falls back to
+// a xoshiro256** generator seeded from crypto/rand, check Hardware to find out which you got.
+//
+// This is NOT a crypto API: nothing checks the hardware's health, and the fallback is predictable. Use crypto/rand
+// for keys. It is unsafe to call from concurrent goroutines, like the other UnsafeRNGs
+type UnsafeHardwareRNG struct {
+	seed     bool
+	hardware bool
+	fallback *UnsafeXoshiro256ssRNG
+}
+
+// NewUnsafeRDRANDRNG creates a generator backed by RDRAND, the fast hardware generator
+func NewUnsafeRDRANDRNG() *UnsafeHardwareRNG {
+	return &UnsafeHardwareRNG{hardware: hasRDRAND}
+}
+
+// NewUnsafeRDSEEDRNG creates a generator backed by RDSEED, which reads the entropy source directly. It is
+// several times slower than RDRAND, and meant for seeding other generators
+func NewUnsafeRDSEEDRNG() *UnsafeHardwareRNG {
+	return &UnsafeHardwareRNG{seed: true, hardware: hasRDSEED}
+}
+
+// Hardware reports whether values still come from the cpu, rather than the software fallback
+func (r *UnsafeHardwareRNG) Hardware() bool {
+	return r.hardware
+}
+
+// Uint64 returns a random uint64 from the cpu, or from the fallback once the cpu has failed to answer
+func (r *UnsafeHardwareRNG) Uint64() uint64 {
+	if r.hardware {
+		if x, ok := r.draw(); ok {
+			return x
+		}
+		r.hardware = false
+	}
+	if r.fallback == nil {
+		r.fallback = NewUnsafeXoshiro256ssRNG(int64(freshSeed()))
+	}
+	return r.fallback.Uint64()
+}
+
+func (r *UnsafeHardwareRNG) draw() (uint64, bool) {
+	if r.seed {
+		for i := 0; i < rdseedRetries; i++ {
+			if x, ok := rdseed64(); ok {
+				return x, true
+			}
+		}
+		return 0, false
+	}
+	for i := 0; i < rdrandRetries; i++ {
+		if x, ok := rdrand64(); ok {
+			return x, true
+		}
+	}
+	return 0, false
+}
+
+// SeedFromHardware returns a seed read with RDSEED, or false if the cpu can't provide one
+func SeedFromHardware() (Seed, bool) {
+	var s Seed
+	if !hasRDSEED {
+		return s, false
+	}
+	r := NewUnsafeRDSEEDRNG()
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(s[i*8:], r.Uint64())
+	}
+	return s, r.Hardware()
+}
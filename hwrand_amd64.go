@@ -0,0 +1,45 @@
+//go:build amd64 && !purego
+
+package fastrand64
+
+// rdrand64 executes RDRAND, ok is false if the cpu had no value ready
+func rdrand64() (x uint64, ok bool)
+
+// rdseed64 executes RDSEED, ok is false if the cpu had no value ready
+func rdseed64() (x uint64, ok bool)
+
+var (
+	hasRDRAND = detectRDRAND()
+	hasRDSEED = detectRDSEED()
+)
+
+func detectRDRAND() bool {
+	_, _, ecx, _ := cpuid(1, 0)
+	return ecx&(1<<30) != 0 && answers(rdrand64)
+}
+
+func detectRDSEED() bool {
+	if maxLeaf, _, _, _ := cpuid(0, 0); maxLeaf < 7 {
+		return false
+	}
+	_, ebx, _, _ := cpuid(7, 0)
+	return ebx&(1<<18) != 0 && answers(rdseed64)
+}
+
+// answers weeds out cpus known to advertise the instruction but return the same value every time,
+// some AMD parts return all ones after a suspend
+func answers(draw func() (uint64, bool)) bool {
+	var first uint64
+	seen := false
+	for i := 0; i < 8; i++ {
+		x, ok := draw()
+		if !ok {
+			continue
+		}
+		if seen && x != first {
+			return true
+		}
+		first, seen = x, true
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// func rdrand64() (x uint64, ok bool)
+TEXT ·rdrand64(SB), NOSPLIT, $0-9
+	RDRANDQ AX
+	SETCS ok+8(FP)
+	MOVQ AX, x+0(FP)
+	RET
+
+// func rdseed64() (x uint64, ok bool)
+TEXT ·rdseed64(SB), NOSPLIT, $0-9
+	RDSEEDQ AX
+	SETCS ok+8(FP)
+	MOVQ AX, x+0(FP)
+	RET
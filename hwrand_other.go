@@ -0,0 +1,12 @@
+//go:build !amd64 || purego
+
+package fastrand64
+
+const (
+	hasRDRAND = false
+	hasRDSEED = false
+)
+
+func rdrand64() (uint64, bool) { return 0, false }
+
+func rdseed64() (uint64, bool) { return 0, false }
@@ -0,0 +1,41 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HardwareRNG(t *testing.T) {
+	for _, r := range []*UnsafeHardwareRNG{NewUnsafeRDRANDRNG(), NewUnsafeRDSEEDRNG()} {
+		t.Logf("hardware: %v", r.Hardware())
+		assert.True(t, passesSmokeCheck(r))
+	}
+	assert.Equal(t, hasRDRAND, NewUnsafeRDRANDRNG().Hardware())
+	assert.Equal(t, hasRDSEED, NewUnsafeRDSEEDRNG().Hardware())
+}
+
+func Test_HardwareRNG_Fallback(t *testing.T) {
+	// a generator whose hardware has given up carries on from software
+	r := &UnsafeHardwareRNG{}
+	assert.False(t, r.Hardware())
+	assert.True(t, passesSmokeCheck(r))
+}
+
+func Test_SeedFromHardware(t *testing.T) {
+	a, ok := SeedFromHardware()
+	assert.Equal(t, hasRDSEED, ok)
+	if ok {
+		b, _ := SeedFromHardware()
+		assert.NotEqual(t, a, b)
+	}
+}
+
+func Benchmark_UnsafeRDRANDRNG(b *testing.B) {
+	r := NewUnsafeRDRANDRNG()
+	var x uint64
+	for i := 0; i < b.N; i++ {
+		x += r.Uint64()
+	}
+	BenchSink = &x
+}
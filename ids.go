@@ -0,0 +1,172 @@
+package fastrand64
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// UUID is a 128 bit universally unique identifier
+type UUID [16]byte
+
+// String returns the canonical 8-4-4-4-12 hex form
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+func uuidv4(hi, lo uint64) UUID {
+	var u UUID
+	binary.BigEndian.PutUint64(u[0:8], hi)
+	binary.BigEndian.PutUint64(u[8:16], lo)
+	u[6] = u[6]&0x0f | 0x40 // version 4
+	u[8] = u[8]&0x3f | 0x80 // RFC 4122 variant
+	return u
+}
+
+// InsecureUUIDv4 returns a version 4 UUID from a thread unsafe RNG.
+//
+// NOT for security: anyone who sees a few of these can predict the rest, so use them for test fixtures and
+// trace ids, and SecureUUIDv4 for session tokens and the like
+func InsecureUUIDv4(r UnsafeRNG) UUID {
+	hi := r.Uint64()
+	return uuidv4(hi, r.Uint64())
+}
+
+// InsecureUUIDv4 returns a version 4 UUID, NOT for security. Threadsafe
+func (s *ThreadsafePoolRNG) InsecureUUIDv4() UUID {
+	hi, lo := s.Uint64Pair()
+	return uuidv4(hi, lo)
+}
+
+// SecureUUIDv4 returns a version 4 UUID read from crypto/rand, for ids that must not be guessable
+func SecureUUIDv4() (UUID, error) {
+	var b [16]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		return UUID{}, err
+	}
+	return uuidv4(binary.BigEndian.Uint64(b[0:8]), binary.BigEndian.Uint64(b[8:16])), nil
+}
+
+// ULID is a 128 bit lexicographically sortable identifier, a 48 bit millisecond timestamp followed
+// by 80 random bits, see https://github.com/ulid/spec
+type ULID [16]byte
+
+// ErrULIDOverflow is returned by MonotonicULID when more ids are asked for within one millisecond
+// than the 80 random bits can count
+var ErrULIDOverflow = errors.New("fastrand64: ULID random component overflow")
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Time returns the timestamp encoded in the ULID, to the millisecond
+func (u ULID) Time() time.Time {
+	ms := uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 | uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5])
+	return time.UnixMilli(int64(ms))
+}
+
+// String returns the 26 character Crockford base32 form, which sorts the same way as the ULID
+func (u ULID) String() string {
+	var buf [26]byte
+	// 130 bits of output for 128 bits of id, so the first character only carries the top 3 bits
+	hi := binary.BigEndian.Uint64(u[0:8])
+	lo := binary.BigEndian.Uint64(u[8:16])
+	for i := 25; i >= 0; i-- {
+		buf[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buf[:])
+}
+
+func (u *ULID) setTime(ms uint64) {
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+}
+
+// NewULID returns a ULID for time t from a thread unsafe RNG, NOT for security
+func NewULID(r UnsafeRNG, t time.Time) ULID {
+	var u ULID
+	u.setTime(uint64(t.UnixMilli()))
+	x := r.Uint64()
+	binary.BigEndian.PutUint16(u[6:8], uint16(x))
+	binary.BigEndian.PutUint64(u[8:16], r.Uint64())
+	return u
+}
+
+// ULID returns a ULID for the current time, NOT for security. Threadsafe
+func (s *ThreadsafePoolRNG) ULID() ULID {
+	r, pool := s.get()
+	u := NewULID(r, time.Now())
+	pool.put(r)
+	return u
+}
+
+// MonotonicULID hands out ULIDs that strictly increase, even within the same millisecond or if the clock
+// steps backwards: in either case the previous id's random part is incremented instead of drawing a new one.
+// Threadsafe
+type MonotonicULID struct {
+	pool *ThreadsafePoolRNG
+	mu   sync.Mutex
+	last ULID
+}
+
+// NewMonotonicULID creates a MonotonicULID drawing its random parts from pool
+func NewMonotonicULID(pool *ThreadsafePoolRNG) *MonotonicULID {
+	return &MonotonicULID{pool: pool}
+}
+
+// Next returns the next ULID for the current time
+func (m *MonotonicULID) Next() (ULID, error) {
+	return m.NextAt(time.Now())
+}
+
+// NextAt returns the next ULID for time t, or ErrULIDOverflow if the random part can't be incremented
+func (m *MonotonicULID) NextAt(t time.Time) (ULID, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ms := uint64(t.UnixMilli())
+	if ms > uint64(m.last.Time().UnixMilli()) || m.last == (ULID{}) {
+		m.last = m.pool.ULID()
+		m.last.setTime(ms)
+		return m.last, nil
+	}
+	next := m.last
+	for i := 15; i >= 6; i-- {
+		next[i]++
+		if next[i] != 0 {
+			m.last = next
+			return next, nil
+		}
+	}
+	return ULID{}, ErrULIDOverflow
+}
+
+// NanoIDSize is the default NanoID length, 126 random bits
+const NanoIDSize = 21
+
+// NanoID returns a NanoID, NanoIDSize characters of the url safe base64 alphabet, from a thread unsafe RNG.
+// For other lengths or alphabets use Alphabet.String. NOT for security
+func NanoID(r UnsafeRNG) string {
+	return AlphabetURLSafe.String(r, NanoIDSize)
+}
+
+// NanoID returns a NanoID, NOT for security. Threadsafe
+func (s *ThreadsafePoolRNG) NanoID() string {
+	return s.StringFrom(AlphabetURLSafe, NanoIDSize)
+}
@@ -0,0 +1,67 @@
+package fastrand64
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UUIDv4(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	re := "^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$"
+	for i := 0; i < 100; i++ {
+		assert.Regexp(t, re, rng.InsecureUUIDv4().String())
+	}
+	assert.Equal(t, InsecureUUIDv4(NewUnsafeXoshiro256ssRNG(1)), InsecureUUIDv4(NewUnsafeXoshiro256ssRNG(1)))
+
+	u, err := SecureUUIDv4()
+	assert.NoError(t, err)
+	assert.Regexp(t, re, u.String())
+}
+
+func Test_ULID(t *testing.T) {
+	at := time.UnixMilli(1469918176385)
+	u := NewULID(NewUnsafeXoshiro256ssRNG(1), at)
+	assert.Equal(t, at, u.Time())
+	assert.Regexp(t, "^01ARYZ6S41[0-9A-HJKMNP-TV-Z]{16}$", u.String())
+
+	var max ULID
+	for i := range max {
+		max[i] = 0xff
+	}
+	assert.Equal(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", max.String())
+}
+
+func Test_MonotonicULID(t *testing.T) {
+	m := NewMonotonicULID(NewSyncPoolXoshiro256ssRNG())
+	at := time.UnixMilli(1000)
+	var ids []string
+	for i := 0; i < 1000; i++ {
+		// the clock stalls and even steps back, ids must still increase
+		u, err := m.NextAt(at.Add(time.Duration(i%3-1) * time.Millisecond))
+		assert.NoError(t, err)
+		ids = append(ids, u.String())
+	}
+	assert.True(t, sort.StringsAreSorted(ids))
+	for i := 1; i < len(ids); i++ {
+		assert.NotEqual(t, ids[i-1], ids[i])
+	}
+
+	m.last.setTime(2000)
+	for i := 6; i < 16; i++ {
+		m.last[i] = 0xff
+	}
+	_, err := m.NextAt(time.UnixMilli(2000))
+	assert.True(t, errors.Is(err, ErrULIDOverflow))
+	u, err := m.NextAt(time.UnixMilli(2001))
+	assert.NoError(t, err)
+	assert.Equal(t, time.UnixMilli(2001), u.Time())
+}
+
+func Test_NanoID(t *testing.T) {
+	assert.Regexp(t, "^[A-Za-z0-9_-]{21}$", NewSyncPoolXoshiro256ssRNG().NanoID())
+	assert.Equal(t, NanoID(NewUnsafeXoshiro256ssRNG(1)), NanoID(NewUnsafeXoshiro256ssRNG(1)))
+}
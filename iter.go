@@ -0,0 +1,34 @@
+package fastrand64
+
+import "iter"
+
+// All returns an iterator over n pseudorandom uint64s. Threadsafe
+//
+// A generator is checked out of the pool once and held for the whole loop, so ranging over it is as cheap as
+// using an unsafe generator directly. Breaking out of the loop early hands the generator back
+func (s *ThreadsafePoolRNG) All(n int) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		r, pool := s.get()
+		defer pool.put(r)
+		for i := 0; i < n; i++ {
+			if !yield(r.Uint64()) {
+				return
+			}
+		}
+	}
+}
+
+// Floats returns an iterator over n pseudorandom float64s in the range [0.0, 1.0). Threadsafe
+//
+// Like All, a generator is held for the whole loop
+func (s *ThreadsafePoolRNG) Floats(n int) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		r, pool := s.get()
+		defer pool.put(r)
+		for i := 0; i < n; i++ {
+			if !yield(Float64(r)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_All(t *testing.T) {
+	rng := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeRandRNG(1) })
+	expected := NewUnsafeRandRNG(1)
+	count := 0
+	for x := range rng.All(100) {
+		assert.Equal(t, expected.Uint64(), x)
+		count++
+	}
+	assert.Equal(t, 100, count)
+
+	count = 0
+	for range rng.All(100) {
+		count++
+		if count == 10 {
+			break
+		}
+	}
+	assert.Equal(t, 10, count)
+}
+
+func Test_SafeRNG_Floats(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	count := 0
+	for x := range rng.Floats(1000) {
+		assert.True(t, x >= 0 && x < 1)
+		count++
+	}
+	assert.Equal(t, 1000, count)
+}
+
+func Benchmark_SyncPoolXoshiro256ssRNG_All(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	var r uint64
+	for x := range rng.All(b.N) {
+		r = x
+	}
+	BenchSink = &r
+}
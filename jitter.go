@@ -0,0 +1,109 @@
+package fastrand64
+
+import (
+	"math"
+	"time"
+)
+
+// capBackoff returns min(cap, base*2^attempt) without overflowing
+func capBackoff(fn string, base, cap time.Duration, attempt int) time.Duration {
+	if base < 0 || cap < base || attempt < 0 {
+		panic("invalid argument to " + fn)
+	}
+	if base == 0 {
+		return 0
+	}
+	if attempt >= 63 || base > cap>>uint(attempt) {
+		return cap
+	}
+	return base << uint(attempt)
+}
+
+// fullJitter returns a duration in [0, backoff), or 0 if the backoff is 0
+func fullJitter(r UnsafeRNG, backoff time.Duration) time.Duration {
+	if backoff == 0 {
+		return 0
+	}
+	return time.Duration(Uint64n(r, uint64(backoff)))
+}
+
+// equalJitter returns a duration in [backoff/2, backoff), or 0 if the backoff is 0
+func equalJitter(r UnsafeRNG, backoff time.Duration) time.Duration {
+	half := backoff / 2
+	return half + fullJitter(r, backoff-half)
+}
+
+// decorrelatedJitter returns min(cap, a duration in [base, prev*3)), prev below base counts as base
+func decorrelatedJitter(r UnsafeRNG, base, cap, prev time.Duration) time.Duration {
+	if prev < base {
+		prev = base
+	}
+	upper := time.Duration(math.MaxInt64)
+	if prev <= math.MaxInt64/3 {
+		upper = prev * 3
+	}
+	d := base
+	if upper > base {
+		d = DurationRange(r, base, upper)
+	}
+	if d > cap {
+		return cap
+	}
+	return d
+}
+
+// FullJitter returns the "full jitter" delay before retry number attempt (counting from 0): a duration drawn
+// uniformly from [0, min(cap, base*2^attempt)). See
+// https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
+//
+// It draws from a package wide pool, so it is safe to call from any goroutine. It panics if base is negative,
+// cap is less than base, or attempt is negative
+func FullJitter(base, cap time.Duration, attempt int) time.Duration {
+	return defaultRNG.FullJitter(base, cap, attempt)
+}
+
+// EqualJitter returns the "equal jitter" delay before retry number attempt: half of min(cap, base*2^attempt)
+// plus a random duration up to the other half, so it never waits less than half the backoff. See FullJitter
+func EqualJitter(base, cap time.Duration, attempt int) time.Duration {
+	return defaultRNG.EqualJitter(base, cap, attempt)
+}
+
+// DecorrelatedJitter returns the "decorrelated jitter" delay given the previous one: a duration drawn uniformly
+// from [base, prev*3), capped at cap. Pass 0 (or base) as prev for the first retry, and the returned delay
+// as prev for the next.
+//
+// It is safe to call from any goroutine, and panics if base is negative or cap is less than base
+func DecorrelatedJitter(base, cap, prev time.Duration) time.Duration {
+	return defaultRNG.DecorrelatedJitter(base, cap, prev)
+}
+
+// FullJitter returns a duration in [0, min(cap, base*2^attempt)), see the package level FullJitter. Threadsafe
+func (s *ThreadsafePoolRNG) FullJitter(base, cap time.Duration, attempt int) time.Duration {
+	backoff := capBackoff("FullJitter", base, cap, attempt)
+	r, pool := s.get()
+	x := fullJitter(r, backoff)
+	pool.put(r)
+	return x
+}
+
+// EqualJitter returns a duration in [backoff/2, backoff) where backoff is min(cap, base*2^attempt), see the
+// package level EqualJitter. Threadsafe
+func (s *ThreadsafePoolRNG) EqualJitter(base, cap time.Duration, attempt int) time.Duration {
+	backoff := capBackoff("EqualJitter", base, cap, attempt)
+	r, pool := s.get()
+	x := equalJitter(r, backoff)
+	pool.put(r)
+	return x
+}
+
+// DecorrelatedJitter returns min(cap, a duration in [base, prev*3)), see the package level DecorrelatedJitter.
+// Threadsafe
+func (s *ThreadsafePoolRNG) DecorrelatedJitter(base, cap, prev time.Duration) time.Duration {
+	if base < 0 || cap < base {
+		panic("invalid argument to DecorrelatedJitter")
+	}
+	r, pool := s.get()
+	x := decorrelatedJitter(r, base, cap, prev)
+	pool.put(r)
+	return x
+}
@@ -0,0 +1,97 @@
+package fastrand64
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_capBackoff(t *testing.T) {
+	assert.Equal(t, 100*time.Millisecond, capBackoff("x", 100*time.Millisecond, time.Second, 0))
+	assert.Equal(t, 800*time.Millisecond, capBackoff("x", 100*time.Millisecond, time.Second, 3))
+	assert.Equal(t, time.Second, capBackoff("x", 100*time.Millisecond, time.Second, 4))
+	// huge attempts saturate rather than overflow
+	assert.Equal(t, time.Duration(1<<62), capBackoff("x", time.Nanosecond, math.MaxInt64, 62))
+	assert.Equal(t, time.Duration(math.MaxInt64), capBackoff("x", time.Nanosecond, math.MaxInt64, 63))
+	assert.Equal(t, time.Duration(math.MaxInt64), capBackoff("x", time.Second, math.MaxInt64, 1000))
+	assert.Equal(t, time.Duration(0), capBackoff("x", 0, time.Second, 1000))
+	assert.Panics(t, func() { capBackoff("x", -1, time.Second, 0) })
+	assert.Panics(t, func() { capBackoff("x", time.Second, time.Millisecond, 0) })
+	assert.Panics(t, func() { capBackoff("x", time.Second, time.Second, -1) })
+}
+
+func Test_FullJitter(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	sum := time.Duration(0)
+	for i := 0; i < 10000; i++ {
+		d := rng.FullJitter(10*time.Millisecond, time.Second, 2)
+		assert.True(t, d >= 0 && d < 40*time.Millisecond)
+		sum += d
+	}
+	assert.InDelta(t, float64(20*time.Millisecond), float64(sum/10000), float64(time.Millisecond))
+	for i := 0; i < 1000; i++ {
+		assert.True(t, FullJitter(time.Millisecond, time.Second, 100) < time.Second)
+	}
+	assert.Equal(t, time.Duration(0), FullJitter(0, time.Second, 3))
+	assert.Panics(t, func() { FullJitter(time.Second, 0, 0) })
+}
+
+func Test_EqualJitter(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	sum := time.Duration(0)
+	for i := 0; i < 10000; i++ {
+		d := rng.EqualJitter(10*time.Millisecond, time.Second, 2)
+		assert.True(t, d >= 20*time.Millisecond && d < 40*time.Millisecond)
+		sum += d
+	}
+	assert.InDelta(t, float64(30*time.Millisecond), float64(sum/10000), float64(time.Millisecond))
+	assert.Equal(t, time.Duration(0), EqualJitter(0, 0, 0))
+	assert.Equal(t, time.Duration(1), EqualJitter(2, 2, 0))
+	assert.Panics(t, func() { EqualJitter(time.Second, time.Second, -1) })
+}
+
+func Test_DecorrelatedJitter(t *testing.T) {
+	base, cap := 10*time.Millisecond, 200*time.Millisecond
+	prev := time.Duration(0)
+	for i := 0; i < 10000; i++ {
+		d := DecorrelatedJitter(base, cap, prev)
+		upper := 3 * prev
+		if prev < base {
+			upper = 3 * base
+		}
+		assert.True(t, d >= base && d <= cap && d < upper)
+		prev = d
+	}
+	// it saturates rather than overflows, and a base equal to the cap is fixed
+	assert.True(t, DecorrelatedJitter(time.Second, math.MaxInt64, math.MaxInt64) >= time.Second)
+	assert.Equal(t, time.Second, DecorrelatedJitter(time.Second, time.Second, 0))
+	assert.Equal(t, time.Duration(0), DecorrelatedJitter(0, time.Second, 0))
+	assert.Panics(t, func() { DecorrelatedJitter(-1, time.Second, 0) })
+}
+
+func Test_Jitter_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				assert.True(t, FullJitter(time.Millisecond, time.Second, i%20) < time.Second)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func Benchmark_FullJitter_Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		r := FullJitter(time.Millisecond, time.Second, 5)
+		for pb.Next() {
+			r = FullJitter(time.Millisecond, time.Second, 5)
+		}
+		BenchSink = &r
+	})
+}
@@ -0,0 +1,42 @@
+package fastrand64
+
+import "math/bits"
+
+// splitmixGamma is the golden ratio increment of the splitmix64 generator
+const splitmixGamma = 0x9E3779B97F4A7C15
+
+// Uint64At returns a pseudorandom uint64 that depends only on seed and index, with no generator state at all.
+// The same pair always gives the same value, so it suits per-item randomness that must be reproducible, like
+// bucketing users into experiments or per-chunk noise in a generated world.
+//
+// For a fixed seed, the values at index 0, 1, 2... are the splitmix64 stream keyed by a hash of seed, so
+// consecutive indexes are as independent as a generator's consecutive outputs. Pure and threadsafe
+func Uint64At(seed, index uint64) uint64 {
+	return Splitmix64(Splitmix64(seed) + index*splitmixGamma)
+}
+
+// Float64At returns a pseudorandom float64 in the range [0.0, 1.0) that depends only on seed and index,
+// see Uint64At
+func Float64At(seed, index uint64) float64 {
+	return float64(Uint64At(seed, index)>>11) / (1 << 53)
+}
+
+// IntnAt returns an unbiased pseudorandom int in the range [0..n) that depends only on seed and index,
+// see Uint64At. The rare draws that Lemire's method rejects are redrawn by rehashing, so it stays pure.
+//
+// It panics if n <= 0
+func IntnAt(seed, index uint64, n int) int {
+	if n <= 0 {
+		panic("invalid argument to IntnAt")
+	}
+	x := Uint64At(seed, index)
+	hi, lo := bits.Mul64(x, uint64(n))
+	if lo < uint64(n) {
+		threshold := -uint64(n) % uint64(n)
+		for lo < threshold {
+			x = Splitmix64(x)
+			hi, lo = bits.Mul64(x, uint64(n))
+		}
+	}
+	return int(hi)
+}
@@ -0,0 +1,73 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Uint64At(t *testing.T) {
+	// the same pair always gives the same value, and neighbours differ
+	assert.Equal(t, Uint64At(1, 2), Uint64At(1, 2))
+	assert.NotEqual(t, Uint64At(1, 2), Uint64At(1, 3))
+	assert.NotEqual(t, Uint64At(1, 2), Uint64At(2, 2))
+	assert.NotEqual(t, Uint64At(0, 1), Uint64At(1, 0))
+
+	// a fixed seed walks the splitmix64 stream
+	state := Splitmix64(7)
+	for i := uint64(0); i < 10; i++ {
+		assert.Equal(t, Splitmix64(state), Uint64At(7, i))
+		state += splitmixGamma
+	}
+
+	// each bit is set about half the time over consecutive indexes
+	var ones [64]int
+	for i := uint64(0); i < 10000; i++ {
+		x := Uint64At(42, i)
+		for b := range ones {
+			ones[b] += int(x >> uint(b) & 1)
+		}
+	}
+	for _, n := range ones {
+		assert.InDelta(t, 5000, n, 6*math.Sqrt(2500))
+	}
+}
+
+func Test_Float64At(t *testing.T) {
+	sum := 0.0
+	for i := uint64(0); i < 10000; i++ {
+		x := Float64At(3, i)
+		assert.True(t, x >= 0 && x < 1)
+		sum += x
+	}
+	assert.InDelta(t, 0.5, sum/10000, 0.02)
+	assert.Equal(t, Float64At(3, 9), Float64At(3, 9))
+}
+
+func Test_IntnAt(t *testing.T) {
+	counts := make([]int, 10)
+	for i := uint64(0); i < 100000; i++ {
+		counts[IntnAt(5, i, 10)]++
+	}
+	for _, n := range counts {
+		assert.InDelta(t, 10000, n, 6*math.Sqrt(9000))
+	}
+	// an n that rejects a quarter of all draws on 64 bit still terminates, and is still pure
+	n := math.MaxInt/2 + 2
+	for i := uint64(0); i < 1000; i++ {
+		x := IntnAt(5, i, n)
+		assert.True(t, x >= 0 && x < n)
+		assert.Equal(t, x, IntnAt(5, i, n))
+	}
+	assert.Equal(t, 0, IntnAt(5, 0, 1))
+	assert.Panics(t, func() { IntnAt(5, 0, 0) })
+}
+
+func Benchmark_Uint64At(b *testing.B) {
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = Uint64At(1, uint64(i))
+	}
+	BenchSink = &r
+}
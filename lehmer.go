@@ -0,0 +1,41 @@
+package fastrand64
+
+import "math/bits"
+
+// lehmerMultiplier is the multiplier of the usual 128 bit Lehmer generator, as used by Lemire and O'Neill
+const lehmerMultiplier = 0xda942042e4dd58b5
+
+// UnsafeLehmer128RNG It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+//
+// Lehmer128 is a 128 bit multiplicative congruential generator that returns the high 64 bits of its state.
+// See https://lemire.me/blog/2019/03/19/the-fastest-conventional-random-number-generator-that-can-pass-big-crush/
+//
+// A step is one 64x64->128 bit multiply and a 64 bit one, making it about as fast as wyrand on 64 bit cpus,
+// and it passes BigCrush. The period is 2^126
+type UnsafeLehmer128RNG struct {
+	owner
+	hi uint64
+	lo uint64 // always odd
+}
+
+// Uint64 generates a random Uint64, (not thread safe)
+func (r *UnsafeLehmer128RNG) Uint64() uint64 {
+	r.checkOwner("UnsafeLehmer128RNG")
+	hi, lo := bits.Mul64(r.lo, lehmerMultiplier)
+	hi += r.hi * lehmerMultiplier
+	r.hi, r.lo = hi, lo
+	return hi
+}
+
+// Seed expands seed with splitmix64 into the 128 bit state, the low half is forced odd as an MCG needs
+func (r *UnsafeLehmer128RNG) Seed(seed int64) {
+	r.hi = Splitmix64(uint64(seed))
+	r.lo = Splitmix64(uint64(seed)+1) | 1
+}
+
+// NewUnsafeLehmer128RNG creates a new Thread unsafe Lehmer128 PRNG generator
+func NewUnsafeLehmer128RNG(seed int64) *UnsafeLehmer128RNG {
+	r := &UnsafeLehmer128RNG{}
+	r.Seed(seed)
+	return r
+}
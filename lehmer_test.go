@@ -0,0 +1,41 @@
+package fastrand64
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnsafeLehmer128RNG_Uint64(t *testing.T) {
+	rng1 := NewUnsafeLehmer128RNG(42)
+	rng2 := NewUnsafeLehmer128RNG(42)
+	for i := 0; i < 256; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+	assert.NotEqual(t, NewUnsafeLehmer128RNG(1).Uint64(), NewUnsafeLehmer128RNG(2).Uint64())
+	assert.True(t, passesSmokeCheck(NewUnsafeLehmer128RNG(0)))
+}
+
+func Test_UnsafeLehmer128RNG_MatchesBigInt(t *testing.T) {
+	rng := NewUnsafeLehmer128RNG(7)
+	state := new(big.Int).Lsh(new(big.Int).SetUint64(rng.hi), 64)
+	state.Or(state, new(big.Int).SetUint64(rng.lo))
+	mod := new(big.Int).Lsh(big.NewInt(1), 128)
+	m := new(big.Int).SetUint64(lehmerMultiplier)
+	for i := 0; i < 100; i++ {
+		state.Mul(state, m).Mod(state, mod)
+		expected := new(big.Int).Rsh(state, 64).Uint64()
+		assert.Equal(t, expected, rng.Uint64())
+		assert.Equal(t, uint64(1), rng.lo&1)
+	}
+}
+
+func Benchmark_UnsafeLehmer128RNG_Uint64(b *testing.B) {
+	rng := NewUnsafeLehmer128RNG(1)
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
+}
@@ -0,0 +1,97 @@
+package fastrand64
+
+import "sync"
+
+// LockedRNG guards any UnsafeRNG with a mutex, so goroutines can share one stream of numbers. Unlike the pool,
+// a LockedRNG seeded the same way always produces the same sequence, just split between the goroutines in
+// whatever order they take the lock. That is handy in tests that need both, but every call contends on the
+// one lock, so use a pool where throughput matters. Threadsafe, and usable anywhere an UnsafeRNG is
+type LockedRNG struct {
+	mu sync.Mutex
+	r  UnsafeRNG
+}
+
+// NewLockedRNG wraps r, which must not be used directly afterwards
+func NewLockedRNG(r UnsafeRNG) *LockedRNG {
+	return &LockedRNG{r: r}
+}
+
+// unlock hands the generator off and unlocks it
+func (l *LockedRNG) unlock() {
+	HandOff(l.r)
+	l.mu.Unlock()
+}
+
+// Uint64 returns pseudorandom uint64. Threadsafe
+func (l *LockedRNG) Uint64() uint64 {
+	l.mu.Lock()
+	x := l.r.Uint64()
+	l.unlock()
+	return x
+}
+
+// Int63 returns a non-negative pseudorandom int64. Threadsafe
+func (l *LockedRNG) Int63() int64 {
+	return int64(0x7FFFFFFFFFFFFFFF & l.Uint64())
+}
+
+// Seed reseeds the wrapped generator, so a LockedRNG is a math/rand Source64. A generator with a Seed(int64)
+// method gets seed as is, one with a Seed([32]byte) method gets a key derived from it, others are left alone.
+// Threadsafe
+func (l *LockedRNG) Seed(seed int64) {
+	l.mu.Lock()
+	defer l.unlock()
+	if g, ok := l.r.(interface{ Seed(seed int64) }); ok {
+		g.Seed(seed)
+		return
+	}
+	var i uint64
+	reseedWith(l.r, func() uint64 {
+		i++
+		return Uint64At(uint64(seed), i-1)
+	})
+}
+
+// Uint64n returns an unbiased pseudorandom uint64 in the range [0..n). Threadsafe
+//
+// It panics if n == 0
+func (l *LockedRNG) Uint64n(n uint64) uint64 {
+	if n == 0 {
+		panic("invalid argument to Uint64n")
+	}
+	l.mu.Lock()
+	x := Uint64n(l.r, n)
+	l.unlock()
+	return x
+}
+
+// Intn returns an unbiased pseudorandom int in the range [0..n). Threadsafe
+//
+// It panics if n <= 0
+func (l *LockedRNG) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	return int(l.Uint64n(uint64(n)))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0.0, 1.0). Threadsafe
+func (l *LockedRNG) Float64() float64 {
+	return float64(l.Uint64()>>11) / (1 << 53)
+}
+
+// Read fills a []byte array with random bytes, in the same little endian order as Bytes. Threadsafe
+func (l *LockedRNG) Read(p []byte) []byte {
+	l.mu.Lock()
+	Bytes(l.r, p)
+	l.unlock()
+	return p
+}
+
+// With calls fn with the wrapped generator while holding the lock, so a run of draws stays together in the
+// sequence and pays for the lock once. The generator must not be used after fn returns
+func (l *LockedRNG) With(fn func(r UnsafeRNG)) {
+	l.mu.Lock()
+	defer l.unlock()
+	fn(l.r)
+}
@@ -0,0 +1,79 @@
+package fastrand64
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LockedRNG_Sequence(t *testing.T) {
+	// the goroutines between them draw exactly the single generator's sequence
+	l := NewLockedRNG(NewUnsafeXoshiro256ssRNG(1))
+	var mu sync.Mutex
+	var got []uint64
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				x := l.Uint64()
+				mu.Lock()
+				got = append(got, x)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	r := NewUnsafeXoshiro256ssRNG(1)
+	expected := make([]uint64, 8000)
+	for i := range expected {
+		expected[i] = r.Uint64()
+	}
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+	sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+	assert.Equal(t, expected, got)
+}
+
+func Test_LockedRNG(t *testing.T) {
+	l := NewLockedRNG(NewUnsafeXoshiro256ssRNG(1))
+	r := NewUnsafeXoshiro256ssRNG(1)
+	assert.Equal(t, Uint64n(r, 10), l.Uint64n(10))
+	assert.Equal(t, Intn(r, 10), l.Intn(10))
+	assert.Equal(t, Float64(r), l.Float64())
+	assert.Equal(t, Bytes(r, make([]byte, 13)), l.Read(make([]byte, 13)))
+	l.With(func(g UnsafeRNG) {
+		assert.Equal(t, r.Uint64(), g.Uint64())
+		assert.Equal(t, r.Uint64(), g.Uint64())
+	})
+	assert.Panics(t, func() { l.Uint64n(0) })
+	assert.Panics(t, func() { l.Intn(0) })
+
+	// it is a math/rand Source64, seeding included
+	rr := rand.New(l)
+	rr.Seed(7)
+	assert.Equal(t, NewUnsafeXoshiro256ssRNG(7).Uint64(), l.Uint64())
+	assert.True(t, rr.Int63() >= 0)
+
+	c := NewLockedRNG(NewUnsafeChaCha8RNG(1))
+	c.Seed(7)
+	first := c.Uint64()
+	c.Seed(7)
+	assert.Equal(t, first, c.Uint64())
+	assert.NotEqual(t, NewUnsafeChaCha8RNG(1).Uint64(), first)
+}
+
+func Benchmark_LockedRNG_Uint64_Parallel(b *testing.B) {
+	l := NewLockedRNG(NewUnsafeXoshiro256ssRNG(1))
+	b.RunParallel(func(pb *testing.PB) {
+		r := l.Uint64()
+		for pb.Next() {
+			r = l.Uint64()
+		}
+		BenchSink = &r
+	})
+}
@@ -0,0 +1,121 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// MultiSource prefers a primary source of randomness, like crypto/rand.Reader or a hardware generator, and
+// transparently fails over to a fast pool when the primary returns an error or gets slow.
+//
+// A failed primary read, or one still running after maxLatency, trips a breaker and the pool serves that call
+// and everything else until the cooldown passes, so even a primary that hangs forever only costs maxLatency.
+// Counters record which path served each call, see Stats. It is threadsafe as long as the primary is
+type MultiSource struct {
+	primary    io.Reader
+	fallback   *ThreadsafePoolRNG
+	maxLatency time.Duration
+	cooldown   time.Duration
+
+	skipUntil      atomic.Int64 // unix nanos, the primary isn't tried again until then
+	primaryServed  atomic.Uint64
+	fallbackServed atomic.Uint64
+	primaryErrors  atomic.Uint64
+	primarySlow    atomic.Uint64
+}
+
+// MultiSourceStats counts the calls served by each path of a MultiSource, and why the primary was skipped
+type MultiSourceStats struct {
+	PrimaryServed  uint64
+	FallbackServed uint64
+	PrimaryErrors  uint64
+	PrimarySlow    uint64
+}
+
+// NewMultiSource creates a MultiSource. maxLatency is the longest to wait for a primary read before failing
+// over and tripping the breaker, and cooldown is how long the breaker then stays open.
+//
+// With a maxLatency each primary read runs on its own goroutine into its own buffer, so one that times out can
+// finish later without touching the caller's bytes. 0 means no limit, reads the primary directly and waits
+// as long as it takes
+func NewMultiSource(primary io.Reader, fallback *ThreadsafePoolRNG, maxLatency, cooldown time.Duration) (*MultiSource, error) {
+	if primary == nil || fallback == nil {
+		return nil, invalidArgument("NewMultiSource: nil primary or fallback")
+	}
+	if maxLatency < 0 || cooldown < 0 {
+		return nil, invalidArgument("NewMultiSource: negative maxLatency or cooldown")
+	}
+	return &MultiSource{primary: primary, fallback: fallback, maxLatency: maxLatency, cooldown: cooldown}, nil
+}
+
+func (m *MultiSource) trip(now time.Time) {
+	m.skipUntil.Store(now.Add(m.cooldown).UnixNano())
+}
+
+// Read fills p with random bytes. It never fails, so the error is always nil
+func (m *MultiSource) Read(p []byte) (int, error) {
+	if time.Now().UnixNano() >= m.skipUntil.Load() {
+		err := m.readPrimary(p)
+		if err == nil {
+			m.primaryServed.Add(1)
+			return len(p), nil
+		}
+		if err == errPrimaryTimeout {
+			m.primarySlow.Add(1)
+		} else {
+			m.primaryErrors.Add(1)
+		}
+		m.trip(time.Now())
+	}
+	m.fallback.Read(p)
+	m.fallbackServed.Add(1)
+	return len(p), nil
+}
+
+// errPrimaryTimeout is returned by readPrimary when the primary took longer than maxLatency
+var errPrimaryTimeout = errors.New("fastrand64: primary read timed out")
+
+// readPrimary fills p from the primary, giving up after maxLatency if there is one
+func (m *MultiSource) readPrimary(p []byte) error {
+	if m.maxLatency == 0 {
+		_, err := io.ReadFull(m.primary, p)
+		return err
+	}
+	buf := make([]byte, len(p))
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.ReadFull(m.primary, buf)
+		done <- err
+	}()
+	timer := time.NewTimer(m.maxLatency)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		if err == nil {
+			copy(p, buf)
+		}
+		return err
+	case <-timer.C:
+		return errPrimaryTimeout
+	}
+}
+
+// Uint64 returns a random uint64, so a MultiSource can be used anywhere an UnsafeRNG is wanted
+func (m *MultiSource) Uint64() uint64 {
+	var buf [8]byte
+	m.Read(buf[:])
+	return binary.LittleEndian.Uint64(buf[:])
+}
+
+// Stats returns a snapshot of the counters
+func (m *MultiSource) Stats() MultiSourceStats {
+	return MultiSourceStats{
+		PrimaryServed:  m.primaryServed.Load(),
+		FallbackServed: m.fallbackServed.Load(),
+		PrimaryErrors:  m.primaryErrors.Load(),
+		PrimarySlow:    m.primarySlow.Load(),
+	}
+}
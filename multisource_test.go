@@ -0,0 +1,92 @@
+package fastrand64
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type flakyReader struct {
+	fail  bool
+	delay time.Duration
+}
+
+func (f *flakyReader) Read(p []byte) (int, error) {
+	time.Sleep(f.delay)
+	if f.fail {
+		return 0, errors.New("no entropy")
+	}
+	for i := range p {
+		p[i] = 0xAA
+	}
+	return len(p), nil
+}
+
+func Test_NewMultiSource_Errors(t *testing.T) {
+	m, err := NewMultiSource(nil, NewSyncPoolXoshiro256ssRNG(), 0, 0)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	assert.Nil(t, m)
+	m, err = NewMultiSource(rand.Reader, NewSyncPoolXoshiro256ssRNG(), -1, 0)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	assert.Nil(t, m)
+}
+
+func Test_MultiSource_Primary(t *testing.T) {
+	m, err := NewMultiSource(rand.Reader, NewSyncPoolXoshiro256ssRNG(), 0, time.Minute)
+	assert.NoError(t, err)
+	m.Uint64()
+	m.Uint64()
+	assert.Equal(t, MultiSourceStats{PrimaryServed: 2}, m.Stats())
+}
+
+func Test_MultiSource_FailsOver(t *testing.T) {
+	primary := &flakyReader{fail: true}
+	m, err := NewMultiSource(primary, NewSyncPoolXoshiro256ssRNG(), 0, time.Hour)
+	assert.NoError(t, err)
+	m.Uint64()
+	primary.fail = false
+	// the breaker is open, so the recovered primary isn't tried yet
+	m.Uint64()
+	assert.Equal(t, MultiSourceStats{FallbackServed: 2, PrimaryErrors: 1}, m.Stats())
+
+	m.cooldown = 0
+	m.trip(time.Now())
+	assert.Equal(t, uint64(0xAAAAAAAAAAAAAAAA), m.Uint64())
+}
+
+func Test_MultiSource_Slow(t *testing.T) {
+	primary := &flakyReader{delay: 50 * time.Millisecond}
+	m, err := NewMultiSource(primary, NewSyncPoolXoshiro256ssRNG(), time.Millisecond, time.Hour)
+	assert.NoError(t, err)
+	// the late primary bytes are thrown away, not used
+	assert.NotEqual(t, uint64(0xAAAAAAAAAAAAAAAA), m.Uint64())
+	m.Uint64()
+	assert.Equal(t, MultiSourceStats{FallbackServed: 2, PrimarySlow: 1}, m.Stats())
+
+	// a primary that answers in time is still used
+	m, _ = NewMultiSource(&flakyReader{}, NewSyncPoolXoshiro256ssRNG(), time.Minute, time.Hour)
+	assert.Equal(t, uint64(0xAAAAAAAAAAAAAAAA), m.Uint64())
+	assert.Equal(t, MultiSourceStats{PrimaryServed: 1}, m.Stats())
+}
+
+type blockedReader chan struct{}
+
+func (b blockedReader) Read(p []byte) (int, error) {
+	<-b
+	return 0, errors.New("unblocked")
+}
+
+func Test_MultiSource_Hung(t *testing.T) {
+	primary := make(blockedReader)
+	defer close(primary)
+	m, err := NewMultiSource(primary, NewSyncPoolXoshiro256ssRNG(), time.Millisecond, time.Hour)
+	assert.NoError(t, err)
+	start := time.Now()
+	m.Uint64()
+	m.Uint64()
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Equal(t, MultiSourceStats{FallbackServed: 2, PrimarySlow: 1}, m.Stats())
+}
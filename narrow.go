@@ -0,0 +1,61 @@
+package fastrand64
+
+import "math/bits"
+
+// Uint32 returns a pseudorandom uint32 from the high bits of one Uint64 of a thread unsafe RNG
+func Uint32(r UnsafeRNG) uint32 {
+	return uint32(r.Uint64() >> 32)
+}
+
+// Uint16 returns a pseudorandom uint16 from the high bits of one Uint64 of a thread unsafe RNG
+func Uint16(r UnsafeRNG) uint16 {
+	return uint16(r.Uint64() >> 48)
+}
+
+// Uint8 returns a pseudorandom uint8 from the high bits of one Uint64 of a thread unsafe RNG
+func Uint8(r UnsafeRNG) uint8 {
+	return uint8(r.Uint64() >> 56)
+}
+
+// Int64 returns a pseudorandom int64 covering the full range, negative values included, from a thread unsafe RNG.
+// Use Int63 for non-negative values
+func Int64(r UnsafeRNG) int64 {
+	return int64(r.Uint64())
+}
+
+// Int returns a pseudorandom int covering the full range, negative values included, from a thread unsafe RNG.
+// Where int is 32 bits it comes from the high bits of the Uint64
+func Int(r UnsafeRNG) int {
+	return int(int64(r.Uint64()) >> (64 - bits.UintSize))
+}
+
+// Uint32 returns pseudorandom uint32. Threadsafe
+//
+// Each Uint64 drawn gives two results, so only every other call checks a generator out of the pool
+func (s *ThreadsafePoolRNG) Uint32() uint32 {
+	return uint32(s.takeBits(32))
+}
+
+// Uint16 returns pseudorandom uint16. Threadsafe
+//
+// Each Uint64 drawn gives four results, so only one call in 4 checks a generator out of the pool
+func (s *ThreadsafePoolRNG) Uint16() uint16 {
+	return uint16(s.takeBits(16))
+}
+
+// Uint8 returns pseudorandom uint8. Threadsafe
+//
+// Each Uint64 drawn gives eight results, so only one call in 8 checks a generator out of the pool
+func (s *ThreadsafePoolRNG) Uint8() uint8 {
+	return uint8(s.takeBits(8))
+}
+
+// Int64 returns pseudorandom int64 covering the full range, negative values included. Threadsafe
+func (s *ThreadsafePoolRNG) Int64() int64 {
+	return int64(s.Uint64())
+}
+
+// Int returns pseudorandom int covering the full range, negative values included. Threadsafe
+func (s *ThreadsafePoolRNG) Int() int {
+	return Int(s)
+}
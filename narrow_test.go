@@ -0,0 +1,98 @@
+package fastrand64
+
+import (
+	"math"
+	"math/bits"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Narrow(t *testing.T) {
+	r := constRNG(0x0123456789ABCDEF)
+	assert.Equal(t, uint32(0x01234567), Uint32(r))
+	assert.Equal(t, uint16(0x0123), Uint16(r))
+	assert.Equal(t, uint8(0x01), Uint8(r))
+	assert.Equal(t, int64(0x0123456789ABCDEF), Int64(r))
+	assert.Equal(t, int64(-1), Int64(constRNG(math.MaxUint64)))
+	assert.Equal(t, -1, Int(constRNG(math.MaxUint64)))
+	if bits.UintSize == 64 {
+		assert.Equal(t, int64(0x0123456789ABCDEF), int64(Int(r)))
+	}
+}
+
+func Test_BitBuffer_Take(t *testing.T) {
+	s := NewSyncPoolRNG(func() UnsafeRNG { return constRNG(0x0123456789ABCDEF) }, WithStats())
+	pools := s.pools.Load()
+	var b bitBuffer
+	// one word gives two Uint32s, four Uint16s or eight Uint8s, high bits first
+	assert.Equal(t, uint64(0x01234567), b.take(pools, 32))
+	assert.Equal(t, uint64(0x89ABCDEF), b.take(pools, 32))
+	assert.Equal(t, uint64(1), s.Stats().Gets)
+	for _, x := range []uint64{0x0123, 0x4567, 0x89AB, 0xCDEF} {
+		assert.Equal(t, x, b.take(pools, 16))
+	}
+	assert.Equal(t, uint64(2), s.Stats().Gets)
+
+	// leftovers too short for the next value are dropped
+	for i := 0; i < 5; i++ {
+		b.take(pools, 8)
+	}
+	assert.Equal(t, uint64(0x01234567), b.take(pools, 32))
+	assert.Equal(t, uint64(4), s.Stats().Gets)
+	assert.Equal(t, uint64(0x0123456789ABCDEF), b.take(pools, 64))
+}
+
+func Test_SafeRNG_Narrow_Buffered(t *testing.T) {
+	// sync.Pool may drop the bit buffer at any time (it does so on purpose under -race), so only check each
+	// value is a piece of the word at the right bit offset, and that most calls don't need a generator
+	pool := func() *ThreadsafePoolRNG {
+		return NewSyncPoolRNG(func() UnsafeRNG { return constRNG(0x0123456789ABCDEF) }, WithStats())
+	}
+	s32, s16, s8 := pool(), pool(), pool()
+	for i := 0; i < 200; i++ {
+		assert.Contains(t, []uint32{0x01234567, 0x89ABCDEF}, s32.Uint32())
+		assert.Contains(t, []uint16{0x0123, 0x4567, 0x89AB, 0xCDEF}, s16.Uint16())
+		assert.Contains(t, []uint8{0x01, 0x23, 0x45, 0x67, 0x89, 0xAB, 0xCD, 0xEF}, s8.Uint8())
+	}
+	assert.Less(t, s16.Stats().Gets, uint64(150))
+	assert.Less(t, s8.Stats().Gets, uint64(100))
+	assert.Equal(t, int64(0x0123456789ABCDEF), s8.Int64())
+}
+
+func Test_SafeRNG_Narrow_Uniform(t *testing.T) {
+	s := NewSyncPoolXoshiro256ssRNG()
+	var counts [256]int
+	for i := 0; i < 256*400; i++ {
+		counts[s.Uint8()]++
+	}
+	for _, n := range counts {
+		assert.InDelta(t, 400, n, 6*math.Sqrt(400))
+	}
+	negative := 0
+	for i := 0; i < 10000; i++ {
+		if s.Int() < 0 {
+			negative++
+		}
+		if s.Int64() < 0 {
+			negative++
+		}
+	}
+	assert.InDelta(t, 10000, negative, 6*math.Sqrt(5000))
+	highBit := 0
+	for i := 0; i < 10000; i++ {
+		highBit += int(s.Uint32()>>31) + int(s.Uint16()>>15)
+	}
+	assert.InDelta(t, 10000, highBit, 6*math.Sqrt(5000))
+}
+
+func Benchmark_SyncPoolXoshiro256ssRNG_Uint8_Parallel(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rng.Uint8()
+		for pb.Next() {
+			r = rng.Uint8()
+		}
+		BenchSink = &r
+	})
+}
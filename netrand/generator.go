@@ -0,0 +1,61 @@
+package netrand
+
+import (
+	"net"
+	"net/netip"
+
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+// Generator provides thread safe versions of the functions in this package, each value checks a generator out
+// of the pool once
+type Generator struct {
+	rng *fastrand64.ThreadsafePoolRNG
+}
+
+// NewGenerator wraps a thread safe pool backed RNG
+func NewGenerator(rng *fastrand64.ThreadsafePoolRNG) *Generator {
+	return &Generator{rng: rng}
+}
+
+// IPv4 returns a uniformly random IPv4 address. Threadsafe
+func (g *Generator) IPv4() netip.Addr {
+	var a netip.Addr
+	g.rng.With(func(r fastrand64.UnsafeRNG) { a = IPv4(r) })
+	return a
+}
+
+// IPv6 returns a uniformly random IPv6 address. Threadsafe
+func (g *Generator) IPv6() netip.Addr {
+	var a netip.Addr
+	g.rng.With(func(r fastrand64.UnsafeRNG) { a = IPv6(r) })
+	return a
+}
+
+// AddrInPrefix returns a uniformly random address within prefix. Threadsafe
+func (g *Generator) AddrInPrefix(prefix netip.Prefix) netip.Addr {
+	var a netip.Addr
+	g.rng.With(func(r fastrand64.UnsafeRNG) { a = AddrInPrefix(r, prefix) })
+	return a
+}
+
+// MAC returns a random locally administered unicast MAC address. Threadsafe
+func (g *Generator) MAC() net.HardwareAddr {
+	var m net.HardwareAddr
+	g.rng.With(func(r fastrand64.UnsafeRNG) { m = MAC(r) })
+	return m
+}
+
+// EphemeralPort returns a random port in the IANA dynamic range. Threadsafe
+func (g *Generator) EphemeralPort() uint16 {
+	var p uint16
+	g.rng.With(func(r fastrand64.UnsafeRNG) { p = EphemeralPort(r) })
+	return p
+}
+
+// Port returns a random port in [min..max]. Threadsafe
+func (g *Generator) Port(min, max uint16) uint16 {
+	var p uint16
+	g.rng.With(func(r fastrand64.UnsafeRNG) { p = Port(r, min, max) })
+	return p
+}
@@ -0,0 +1,109 @@
+// Package netrand generates random network test fixtures, IP addresses, MAC addresses and ports, on top of
+// fastrand64, for load tests and packet generators.
+//
+// Every function takes any fastrand64.UnsafeRNG as its source, which is not safe to share between goroutines.
+// Wrap a ThreadsafePoolRNG in a Generator to get thread safe versions.
+//
+// Example:
+//
+//	rng := fastrand64.NewSyncPoolXoshiro256ssRNG()
+//	g := netrand.NewGenerator(rng)
+//
+//	// somewhere later, in some goproc
+//	src := g.AddrInPrefix(netip.MustParsePrefix("10.0.0.0/8"))
+//	port := g.EphemeralPort()
+package netrand
+
+import (
+	"encoding/binary"
+	"net"
+	"net/netip"
+
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+// Ephemeral ports are the dynamic range IANA reserves for client side ports, RFC 6335
+const (
+	EphemeralPortMin = 49152
+	EphemeralPortMax = 65535
+)
+
+// IPv4 returns a uniformly random IPv4 address, any of the 2^32 including reserved ones like 0.0.0.0 and
+// 127.0.0.1, use AddrInPrefix to stay within a network
+func IPv4(r fastrand64.UnsafeRNG) netip.Addr {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(r.Uint64()>>32))
+	return netip.AddrFrom4(b)
+}
+
+// IPv6 returns a uniformly random IPv6 address, use AddrInPrefix to stay within a network
+func IPv6(r fastrand64.UnsafeRNG) netip.Addr {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], r.Uint64())
+	binary.BigEndian.PutUint64(b[8:], r.Uint64())
+	return netip.AddrFrom16(b)
+}
+
+// AddrInPrefix returns a uniformly random address within prefix, IPv4 or IPv6, keeping the prefix bits and
+// randomizing the rest. The network and broadcast addresses can come up too.
+//
+// It panics if prefix is not valid
+func AddrInPrefix(r fastrand64.UnsafeRNG, prefix netip.Prefix) netip.Addr {
+	if !prefix.IsValid() {
+		panic("invalid argument to AddrInPrefix")
+	}
+	prefix = prefix.Masked()
+	if prefix.Addr().Is4() {
+		base := prefix.Addr().As4()
+		x := uint32(r.Uint64()>>32) & hostMask32(prefix.Bits())
+		binary.BigEndian.PutUint32(base[:], binary.BigEndian.Uint32(base[:])|x)
+		return netip.AddrFrom4(base)
+	}
+	base := prefix.Addr().As16()
+	hi := r.Uint64() & hostMask64(prefix.Bits())
+	lo := r.Uint64() & hostMask64(prefix.Bits()-64)
+	binary.BigEndian.PutUint64(base[:8], binary.BigEndian.Uint64(base[:8])|hi)
+	binary.BigEndian.PutUint64(base[8:], binary.BigEndian.Uint64(base[8:])|lo)
+	return netip.AddrFrom16(base)
+}
+
+// hostMask32 has the low 32-bits bits set, the host part of a /bits network
+func hostMask32(bits int) uint32 {
+	if bits >= 32 {
+		return 0
+	}
+	return ^uint32(0) >> bits
+}
+
+// hostMask64 has the low 64-bits bits set, bits may be negative or over 64
+func hostMask64(bits int) uint64 {
+	switch {
+	case bits <= 0:
+		return ^uint64(0)
+	case bits >= 64:
+		return 0
+	}
+	return ^uint64(0) >> bits
+}
+
+// MAC returns a random locally administered unicast MAC address, so it can't clash with a real vendor's
+func MAC(r fastrand64.UnsafeRNG) net.HardwareAddr {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], r.Uint64())
+	// set the locally administered bit, clear the multicast bit
+	b[0] = b[0]&^0x01 | 0x02
+	return net.HardwareAddr(b[:6])
+}
+
+// EphemeralPort returns a random port in the IANA dynamic range, [EphemeralPortMin..EphemeralPortMax]
+func EphemeralPort(r fastrand64.UnsafeRNG) uint16 {
+	return uint16(EphemeralPortMin + fastrand64.Uint64n(r, EphemeralPortMax-EphemeralPortMin+1))
+}
+
+// Port returns a random port in [min..max]. It panics if min is 0 or min > max
+func Port(r fastrand64.UnsafeRNG, min, max uint16) uint16 {
+	if min == 0 || min > max {
+		panic("invalid argument to Port")
+	}
+	return min + uint16(fastrand64.Uint64n(r, uint64(max-min)+1))
+}
@@ -0,0 +1,87 @@
+package netrand
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+func Test_IPs(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	a, b := IPv4(rng), IPv4(rng)
+	assert.True(t, a.Is4())
+	assert.NotEqual(t, a, b)
+	c := IPv6(rng)
+	assert.True(t, c.Is6() && !c.Is4In6())
+}
+
+func Test_AddrInPrefix(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	for _, s := range []string{"10.0.0.0/8", "192.168.1.77/24", "1.2.3.4/32", "0.0.0.0/0", "2001:db8::/32", "fe80::/10", "2001:db8::1/128", "2001:db8:0:0:1::/80", "::/0"} {
+		p := netip.MustParsePrefix(s)
+		seen := map[netip.Addr]bool{}
+		for i := 0; i < 100; i++ {
+			a := AddrInPrefix(rng, p)
+			assert.True(t, p.Contains(a), "%v not in %v", a, p)
+			seen[a] = true
+		}
+		if p.Addr().BitLen()-p.Bits() >= 16 {
+			assert.True(t, len(seen) > 90, s)
+		}
+	}
+
+	// every host bit gets set sometimes
+	p := netip.MustParsePrefix("172.16.0.0/12")
+	var or uint32
+	for i := 0; i < 1000; i++ {
+		b := AddrInPrefix(rng, p).As4()
+		or |= uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+	}
+	assert.Equal(t, uint32(0xAC1FFFFF), or)
+
+	assert.Panics(t, func() { AddrInPrefix(rng, netip.Prefix{}) })
+}
+
+func Test_MAC(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	for i := 0; i < 100; i++ {
+		m := MAC(rng)
+		assert.Equal(t, 6, len(m))
+		assert.Equal(t, byte(0x02), m[0]&0x03)
+	}
+}
+
+func Test_Ports(t *testing.T) {
+	rng := fastrand64.NewUnsafeXoshiro256ssRNG(1)
+	lowest, highest := uint16(65535), uint16(0)
+	for i := 0; i < 100000; i++ {
+		p := EphemeralPort(rng)
+		if p < lowest {
+			lowest = p
+		}
+		if p > highest {
+			highest = p
+		}
+	}
+	assert.Equal(t, uint16(EphemeralPortMin), lowest)
+	assert.Equal(t, uint16(EphemeralPortMax), highest)
+
+	assert.Equal(t, uint16(80), Port(rng, 80, 80))
+	p := Port(rng, 1, 65535)
+	assert.True(t, p >= 1)
+	assert.Panics(t, func() { Port(rng, 0, 10) })
+	assert.Panics(t, func() { Port(rng, 10, 9) })
+}
+
+func Test_Generator(t *testing.T) {
+	g := NewGenerator(fastrand64.NewSyncPoolXoshiro256ssRNG())
+	assert.True(t, g.IPv4().Is4())
+	assert.True(t, g.IPv6().Is6())
+	p := netip.MustParsePrefix("10.1.0.0/16")
+	assert.True(t, p.Contains(g.AddrInPrefix(p)))
+	assert.Equal(t, 6, len(g.MAC()))
+	assert.True(t, g.EphemeralPort() >= EphemeralPortMin)
+	assert.Equal(t, uint16(443), g.Port(443, 443))
+}
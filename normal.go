@@ -0,0 +1,96 @@
+package fastrand64
+
+import "math"
+
+// Ziggurat tables for the standard normal distribution, see
+// Marsaglia & Tsang, "The Ziggurat Method for Generating Random Variables" (2000), and
+// Doornik, "An Improved Ziggurat Method to Generate Normal Random Samples" (2005)
+const (
+	normLayers = 128
+	normR      = 3.442619855899      // start of the tail
+	normV      = 9.91256303526217e-3 // area of each layer
+	normLayerM = normLayers - 1      // mask to pick a layer from the low bits
+	normScale  = 1.0 / (1 << 52)     // turns 53 bits into a float in [0, 2)
+)
+
+var (
+	// normX[i] is the right edge of layer i, normX[0] is the width of the virtual base layer
+	normX [normLayers + 1]float64
+	// normRatio[i] is normX[i+1]/normX[i], anything inside it is accepted without evaluating exp
+	normRatio [normLayers]float64
+)
+
+func init() {
+	f := math.Exp(-0.5 * normR * normR)
+	normX[0] = normV / f
+	normX[1] = normR
+	normX[normLayers] = 0
+	for i := 2; i < normLayers; i++ {
+		normX[i] = math.Sqrt(-2 * math.Log(normV/normX[i-1]+f))
+		f = math.Exp(-0.5 * normX[i] * normX[i])
+	}
+	for i := 0; i < normLayers; i++ {
+		normRatio[i] = normX[i+1] / normX[i]
+	}
+}
+
+// NormFloat64 returns a normally distributed float64 with mean 0 and standard deviation 1
+// from a thread unsafe RNG, using the Ziggurat method
+//
+// Almost every call costs a single Uint64, the low 7 bits pick the layer and the top 53 bits the position
+func NormFloat64(r UnsafeRNG) float64 {
+	for {
+		x := r.Uint64()
+		i := x & normLayerM
+		u := float64(x>>11)*normScale - 1
+		if math.Abs(u) < normRatio[i] {
+			return u * normX[i]
+		}
+		if i == 0 {
+			return normTail(r, u < 0)
+		}
+		// in the wedge between the rectangle and the curve, so test against the density itself
+		z := u * normX[i]
+		f0 := math.Exp(-0.5 * (normX[i]*normX[i] - z*z))
+		f1 := math.Exp(-0.5 * (normX[i+1]*normX[i+1] - z*z))
+		if f1+Float64(r)*(f0-f1) < 1 {
+			return z
+		}
+	}
+}
+
+// normTail samples from the normal tail beyond normR using Marsaglia's method
+func normTail(r UnsafeRNG, negative bool) float64 {
+	for {
+		x := math.Log(Float64OpenClosed(r)) / normR
+		y := math.Log(Float64OpenClosed(r))
+		if -2*y >= x*x {
+			if negative {
+				return x - normR
+			}
+			return normR - x
+		}
+	}
+}
+
+// NormFloat64MeanStd returns a normally distributed float64 with mean mu and standard deviation sigma
+// from a thread unsafe RNG
+func NormFloat64MeanStd(r UnsafeRNG, mu, sigma float64) float64 {
+	return mu + sigma*NormFloat64(r)
+}
+
+// NormFloat64 returns a normally distributed float64 with mean 0 and standard deviation 1. Threadsafe
+func (s *ThreadsafePoolRNG) NormFloat64() float64 {
+	r, pool := s.get()
+	x := NormFloat64(r)
+	pool.put(r)
+	return x
+}
+
+// NormFloat64MeanStd returns a normally distributed float64 with mean mu and standard deviation sigma. Threadsafe
+func (s *ThreadsafePoolRNG) NormFloat64MeanStd(mu, sigma float64) float64 {
+	r, pool := s.get()
+	x := NormFloat64MeanStd(r, mu, sigma)
+	pool.put(r)
+	return x
+}
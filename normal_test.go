@@ -0,0 +1,47 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sampleMoments returns the mean and variance of n samples from fn
+func sampleMoments(n int, fn func() float64) (float64, float64) {
+	sum := 0.0
+	sumSq := 0.0
+	for i := 0; i < n; i++ {
+		x := fn()
+		sum += x
+		sumSq += x * x
+	}
+	mean := sum / float64(n)
+	return mean, sumSq/float64(n) - mean*mean
+}
+
+func Test_NormFloat64(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	tail := 0
+	mean, variance := sampleMoments(1000000, func() float64 {
+		x := NormFloat64(rng)
+		if math.Abs(x) > normR {
+			tail++
+		}
+		return x
+	})
+	assert.InDelta(t, 0.0, mean, 0.01)
+	assert.InDelta(t, 1.0, variance, 0.01)
+	// P(|x| > 3.4426) is about 0.000576
+	assert.InDelta(t, 576, tail, 150)
+}
+
+func Test_SafeRNG_NormFloat64MeanStd(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	mean, variance := sampleMoments(100000, func() float64 { return rng.NormFloat64MeanStd(10, 2) })
+	assert.InDelta(t, 10.0, mean, 0.05)
+	assert.InDelta(t, 4.0, variance, 0.1)
+
+	mean, _ = sampleMoments(100000, rng.NormFloat64)
+	assert.InDelta(t, 0.0, mean, 0.02)
+}
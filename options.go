@@ -0,0 +1,195 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"expvar"
+	"io"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+	"weak"
+)
+
+// PoolOption configures NewSyncPoolRNG and TryNewSyncPoolRNG
+type PoolOption func(*poolConfig)
+
+type poolConfig struct {
+	prewarm     int
+	seeds       io.Reader
+	generator   string
+	reseedEvery time.Duration
+	stats       bool
+	pinned      int
+	expvarName  string
+	statsEvery  time.Duration
+	statsFn     func(PoolStats)
+}
+
+// WithPrewarm creates n generators up front, so the first calls on every core don't pay for seeding them.
+// The pool may still drop them in a garbage collection, like anything in a sync.Pool
+func WithPrewarm(n int) PoolOption {
+	return func(c *poolConfig) { c.prewarm = n }
+}
+
+// WithSeedSource seeds every generator the pool creates with 8 bytes read from r, for example a file of
+// recorded seeds, or crypto/rand.Reader. Reads are serialized, and if r fails crypto/rand is used instead.
+// Generators from the pool's func are reseeded if they have a Seed(int64) or Seed([32]byte) method
+func WithSeedSource(r io.Reader) PoolOption {
+	return func(c *poolConfig) { c.seeds = r }
+}
+
+// WithGenerator backs the pool with a generator by name, any name New takes.
+// The pool's func must then be nil
+func WithGenerator(name string) PoolOption {
+	return func(c *poolConfig) { c.generator = name }
+}
+
+// WithReseedInterval calls InvalidateAndReseed every d, so no generator's stream runs for longer than that.
+// The timer doesn't keep the pool alive, it stops once the pool is garbage
+func WithReseedInterval(d time.Duration) PoolOption {
+	return func(c *poolConfig) { c.reseedEvery = d }
+}
+
+// WithPinned keeps up to n idle generators in slots of the pool's own, which unlike a sync.Pool are not emptied by
+// garbage collection, so generators aren't lost and recreated after every GC. Generators beyond n overflow into the
+// sync.Pool as usual. n = 0 means GOMAXPROCS.
+//
+// Taking and returning a pinned generator costs a couple of atomic operations each, where sync.Pool needs none,
+// so this trades some speed for steady allocation, worth it when GC churn shows up in profiles
+func WithPinned(n int) PoolOption {
+	return func(c *poolConfig) {
+		c.pinned = n
+		if n == 0 {
+			c.pinned = runtime.GOMAXPROCS(0)
+		}
+	}
+}
+
+// WithStats turns on the counters returned by Stats. They cost an atomic add or two per call, on counters
+// shared by every core, so measure before leaving them on in a hot path
+func WithStats() PoolOption {
+	return func(c *poolConfig) { c.stats = true }
+}
+
+// WithExpvar turns on Stats and publishes them with expvar under name, so they show up in /debug/vars
+func WithExpvar(name string) PoolOption {
+	return func(c *poolConfig) {
+		c.stats = true
+		c.expvarName = name
+	}
+}
+
+// WithStatsCallback turns on Stats and calls fn with them every interval, from its own goroutine, to feed
+// whatever metrics system the service uses. Like WithReseedInterval it stops once the pool is garbage
+func WithStatsCallback(interval time.Duration, fn func(PoolStats)) PoolOption {
+	return func(c *poolConfig) {
+		c.stats = true
+		c.statsEvery = interval
+		c.statsFn = fn
+	}
+}
+
+// seedReader hands out seeds read from a reader, from any goroutine
+type seedReader struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+func (s *seedReader) next() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var b [8]byte
+	if _, err := io.ReadFull(s.r, b[:]); err != nil {
+		return freshSeed()
+	}
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// newConfiguredPool applies opts on top of fn
+func newConfiguredPool(fn func() UnsafeRNG, opts []PoolOption) (*ThreadsafePoolRNG, error) {
+	var cfg poolConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.prewarm < 0 {
+		return nil, invalidArgument("WithPrewarm: %d generators", cfg.prewarm)
+	}
+	if cfg.pinned < 0 {
+		return nil, invalidArgument("WithPinned: %d generators", cfg.pinned)
+	}
+	if cfg.reseedEvery < 0 {
+		return nil, invalidArgument("WithReseedInterval: %v", cfg.reseedEvery)
+	}
+	if cfg.statsFn != nil && cfg.statsEvery <= 0 || cfg.statsFn == nil && cfg.statsEvery != 0 {
+		return nil, invalidArgument("WithStatsCallback: needs a func and a positive interval")
+	}
+	if cfg.expvarName != "" && expvar.Get(cfg.expvarName) != nil {
+		return nil, invalidArgument("WithExpvar %q: name already published", cfg.expvarName)
+	}
+	var seeds *seedReader
+	if cfg.seeds != nil {
+		seeds = &seedReader{r: cfg.seeds}
+	}
+	switch {
+	case cfg.generator != "":
+		if fn != nil {
+			return nil, invalidArgument("WithGenerator: the pool already has a generator func")
+		}
+		factory, ok := lookupGenerator(cfg.generator)
+		if !ok {
+			return nil, invalidArgument("WithGenerator %q: unknown generator", cfg.generator)
+		}
+		if seeds != nil {
+			fn = func() UnsafeRNG { return factory(int64(seeds.next())) }
+		} else {
+			rand.Seed(time.Now().UnixNano())
+			fn = func() UnsafeRNG { return factory(int64(rand.Uint64())) }
+		}
+	case fn == nil:
+		return nil, invalidArgument("NewSyncPoolRNG: nil generator func")
+	case seeds != nil:
+		base := fn
+		fn = func() UnsafeRNG {
+			r := base()
+			reseedWith(r, seeds.next)
+			return r
+		}
+	}
+
+	s := NewSyncPoolRNG(fn)
+	if cfg.stats || cfg.pinned > 0 {
+		if cfg.stats {
+			s.stats = &poolStats{}
+		}
+		s.pinned = cfg.pinned
+		s.pools.Store(s.newPools(fn))
+	}
+	pools := s.pools.Load()
+	for i := 0; i < cfg.prewarm; i++ {
+		pools.release(pools.rngPool.New().(UnsafeRNG))
+	}
+	if cfg.expvarName != "" {
+		s.publishExpvar(cfg.expvarName)
+	}
+	if cfg.statsFn != nil {
+		s.reportStatsEvery(cfg.statsEvery, cfg.statsFn)
+	}
+	if cfg.reseedEvery > 0 {
+		s.reseedEvery(cfg.reseedEvery)
+	}
+	return s, nil
+}
+
+// reseedEvery reseeds the pool every d for as long as it is reachable
+func (s *ThreadsafePoolRNG) reseedEvery(d time.Duration) {
+	w := weak.Make(s)
+	var tick func()
+	tick = func() {
+		if s := w.Value(); s != nil {
+			s.InvalidateAndReseed()
+			time.AfterFunc(d, tick)
+		}
+	}
+	time.AfterFunc(d, tick)
+}
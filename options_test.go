@@ -0,0 +1,83 @@
+package fastrand64
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PoolOptions_Errors(t *testing.T) {
+	xoshiro := func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(1) }
+	for _, c := range []struct {
+		fn   func() UnsafeRNG
+		opts []PoolOption
+	}{
+		{nil, []PoolOption{WithPrewarm(1)}},
+		{nil, []PoolOption{WithGenerator("nope")}},
+		{xoshiro, []PoolOption{WithGenerator("wyrand")}},
+		{xoshiro, []PoolOption{WithPrewarm(-1)}},
+		{xoshiro, []PoolOption{WithReseedInterval(-time.Second)}},
+	} {
+		s, err := TryNewSyncPoolRNG(c.fn, c.opts...)
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+		assert.Nil(t, s)
+		assert.Panics(t, func() { NewSyncPoolRNG(c.fn, c.opts...) })
+	}
+}
+
+func Test_PoolOptions_Generator(t *testing.T) {
+	s, err := TryNewSyncPoolRNG(nil, WithGenerator("wyrand"))
+	assert.NoError(t, err)
+	r, pool := s.get()
+	_, ok := r.(*UnsafeWyrandRNG)
+	assert.True(t, ok)
+	pool.put(r)
+}
+
+func seedBytes(seeds ...uint64) *bytes.Reader {
+	b := make([]byte, 8*len(seeds))
+	for i, seed := range seeds {
+		binary.LittleEndian.PutUint64(b[i*8:], seed)
+	}
+	return bytes.NewReader(b)
+}
+
+func Test_PoolOptions_SeedSource(t *testing.T) {
+	s := NewSyncPoolRNG(nil, WithGenerator("xoshiro256ss"), WithSeedSource(seedBytes(42)))
+	assert.Equal(t, NewUnsafeXoshiro256ssRNG(42).Uint64(), s.Uint64())
+
+	// generators from a func are reseeded
+	s = NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(1) }, WithSeedSource(seedBytes(42)))
+	assert.Equal(t, NewUnsafeXoshiro256ssRNG(42).Uint64(), s.Uint64())
+
+	// a source that runs dry falls back to crypto/rand rather than failing
+	s = NewSyncPoolRNG(nil, WithGenerator("xoshiro256ss"), WithSeedSource(seedBytes()))
+	assert.NotEqual(t, s.Uint64(), s.Uint64())
+}
+
+func Test_PoolOptions_Prewarm(t *testing.T) {
+	var made int64
+	fn := func() UnsafeRNG {
+		atomic.AddInt64(&made, 1)
+		return NewUnsafeXoshiro256ssRNG(made)
+	}
+	NewSyncPoolRNG(fn, WithPrewarm(4))
+	assert.Equal(t, int64(4), atomic.LoadInt64(&made))
+}
+
+func Test_PoolOptions_ReseedInterval(t *testing.T) {
+	s := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(1) }, WithReseedInterval(time.Millisecond))
+	before := s.pools.Load()
+	deadline := time.Now().Add(5 * time.Second)
+	for s.pools.Load() == before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	assert.NotEqual(t, before, s.pools.Load())
+	// and the replacement generators are freshly seeded rather than all starting from seed 1
+	assert.NotEqual(t, NewUnsafeXoshiro256ssRNG(1).Uint64(), s.Uint64())
+}
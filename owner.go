@@ -0,0 +1,24 @@
+//go:build !fastranddebug
+
+package fastrand64
+
+// owner is embedded in the thread unsafe generators. Built with the fastranddebug tag it panics when a generator
+// is used from a second goroutine without being handed off, otherwise it is empty and costs nothing
+type owner struct{}
+
+func (o *owner) checkOwner(name string) {}
+
+func (o *owner) handOff() {}
+
+// CheckOwnership wraps any UnsafeRNG so that, built with the fastranddebug tag, using it from two goroutines
+// panics instead of silently corrupting its state. Without the tag it returns r unchanged.
+//
+// The generators in this package check themselves under the tag and don't need wrapping
+func CheckOwnership(r UnsafeRNG) UnsafeRNG {
+	return r
+}
+
+// HandOff releases a generator so another goroutine may take it over, when built with the fastranddebug tag.
+// Call it before passing a thread unsafe generator to another goroutine, the pools do so on every put.
+// Without the tag it does nothing
+func HandOff(r UnsafeRNG) {}
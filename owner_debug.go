@@ -0,0 +1,82 @@
+//go:build fastranddebug
+
+package fastrand64
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// owner records the goroutine using a thread unsafe generator, 0 when nobody has used it since it was handed off
+type owner struct {
+	id atomic.Uint64
+}
+
+func (o *owner) checkOwner(name string) {
+	g := goroutineID()
+	id := o.id.Load()
+	if id == g || id == 0 && o.id.CompareAndSwap(0, g) {
+		return
+	}
+	panic(fmt.Sprintf("fastrand64: %s used from goroutine %d while goroutine %d owns it, see HandOff",
+		name, g, o.id.Load()))
+}
+
+func (o *owner) handOff() {
+	o.id.Store(0)
+}
+
+// stackBufs recycles goroutineID's buffers, runtime.Stack makes them escape
+var stackBufs = sync.Pool{New: func() interface{} { return new([64]byte) }}
+
+// goroutineID parses the id out of the "goroutine 123 [running]:" header of a stack trace, slow but only
+// built with fastranddebug
+func goroutineID() uint64 {
+	buf := stackBufs.Get().(*[64]byte)
+	defer stackBufs.Put(buf)
+	b := bytes.TrimPrefix(buf[:runtime.Stack(buf[:], false)], []byte("goroutine "))
+	var id uint64
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			break
+		}
+		id = id*10 + uint64(c-'0')
+	}
+	if id == 0 {
+		panic("fastrand64: can't parse goroutine id from " + strconv.Quote(string(buf[:])))
+	}
+	return id
+}
+
+type ownedRNG struct {
+	owner
+	r UnsafeRNG
+}
+
+func (o *ownedRNG) Uint64() uint64 {
+	o.checkOwner("UnsafeRNG")
+	return o.r.Uint64()
+}
+
+func (o *ownedRNG) handOff() {
+	o.owner.handOff()
+	HandOff(o.r)
+}
+
+// CheckOwnership wraps any UnsafeRNG so that using it from two goroutines panics instead of silently
+// corrupting its state. Without the fastranddebug tag it returns r unchanged
+func CheckOwnership(r UnsafeRNG) UnsafeRNG {
+	return &ownedRNG{r: r}
+}
+
+// HandOff releases a generator so another goroutine may take it over. Call it before passing a thread unsafe
+// generator to another goroutine, the pools do so on every put
+func HandOff(r UnsafeRNG) {
+	if h, ok := r.(interface{ handOff() }); ok {
+		h.handOff()
+	}
+}
@@ -0,0 +1,72 @@
+//go:build fastranddebug
+
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// onOtherGoroutine runs fn on a new goroutine and returns what it panicked with, if anything
+func onOtherGoroutine(fn func()) (recovered interface{}) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() { recovered = recover() }()
+		fn()
+	}()
+	<-done
+	return recovered
+}
+
+func Test_Owner_Panics(t *testing.T) {
+	for _, r := range []UnsafeRNG{
+		NewUnsafeXoshiro256ssRNG(1),
+		NewUnsafeWyrandRNG(1),
+		NewUnsafeXoshiro256ssX4RNG(1),
+		NewUnsafeAESCTRRNG(1),
+		NewUnsafeLehmer128RNG(1),
+		NewUnsafeXoroshiro128pRNG(1),
+		NewUnsafeXoroshiro128ssRNG(1),
+		CheckOwnership(NewUnsafeRandRNG(1)),
+	} {
+		r.Uint64()
+		assert.NotNil(t, onOtherGoroutine(func() { r.Uint64() }), "%T", r)
+
+		HandOff(r)
+		assert.Nil(t, onOtherGoroutine(func() { r.Uint64() }), "%T", r)
+		assert.NotNil(t, onOtherGoroutine(func() { r.Uint64() }), "%T", r)
+	}
+}
+
+func Test_Owner_Bulk(t *testing.T) {
+	r := NewUnsafeXoshiro256ssX4RNG(1)
+	r.Uint64()
+	assert.NotNil(t, onOtherGoroutine(func() { Bytes(r, make([]byte, 64)) }))
+}
+
+func Test_Owner_Pools(t *testing.T) {
+	// the pools hand generators off between goroutines themselves
+	pool := NewSyncPoolXoshiro256ssRNG()
+	xp := NewThreadsafeXoshiroPool()
+	striped := NewStripedRNG(1)
+	fp := NewFloat64PoolXoshiro256ss()
+	for i := 0; i < 8; i++ {
+		assert.Nil(t, onOtherGoroutine(func() {
+			pool.Uint64()
+			xp.Uint64()
+			striped.Uint64()
+			fp.Fill(make([]float64, 300))
+		}))
+	}
+}
+
+func Test_goroutineID(t *testing.T) {
+	id := goroutineID()
+	assert.Equal(t, id, goroutineID())
+	var other uint64
+	onOtherGoroutine(func() { other = goroutineID() })
+	assert.NotEqual(t, id, other)
+	assert.Equal(t, 0.0, testing.AllocsPerRun(10, func() { goroutineID() }))
+}
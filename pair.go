@@ -0,0 +1,41 @@
+package fastrand64
+
+// Uint64Pair returns two pseudorandom uint64s, checking a generator out of the pool only once. Threadsafe
+func (s *ThreadsafePoolRNG) Uint64Pair() (uint64, uint64) {
+	r, pool := s.get()
+	x := r.Uint64()
+	y := r.Uint64()
+	pool.put(r)
+	return x, y
+}
+
+// Int63Pair returns two non-negative pseudorandom int64s, checking a generator out of the pool only once. Threadsafe
+func (s *ThreadsafePoolRNG) Int63Pair() (int64, int64) {
+	x, y := s.Uint64Pair()
+	return int64(0x7FFFFFFFFFFFFFFF & x), int64(0x7FFFFFFFFFFFFFFF & y)
+}
+
+// With calls fn with a generator checked out of the pool, so any number of draws pays for the pool only once.
+// Threadsafe, but the generator must not be used after fn returns or shared with other goroutines
+func (s *ThreadsafePoolRNG) With(fn func(r UnsafeRNG)) {
+	r, pool := s.get()
+	fn(r)
+	pool.put(r)
+}
+
+// Acquire checks a generator out of the pool until release is called, for hot loops that want to draw from it
+// directly with no pool overhead per call. Threadsafe, but the generator belongs to the calling goroutine until
+// released and must not be used afterwards. Calling release more than once does nothing
+//
+//	r, release := rng.Acquire()
+//	defer release()
+func (s *ThreadsafePoolRNG) Acquire() (UnsafeRNG, func()) {
+	r, pool := s.get()
+	released := false
+	return r, func() {
+		if !released {
+			released = true
+			pool.put(r)
+		}
+	}
+}
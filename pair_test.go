@@ -0,0 +1,83 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_Uint64Pair(t *testing.T) {
+	// sync.Pool may hand out a new generator for any call (it drops items on purpose under -race), but both
+	// values of a pair always come one after the other from the same stream
+	rng1 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeRandRNG(1) })
+	rng2 := NewUnsafeRandRNG(1)
+	next := map[uint64]uint64{}
+	prev := rng2.Uint64()
+	for i := 0; i < 1024; i++ {
+		x := rng2.Uint64()
+		next[prev] = x
+		prev = x
+	}
+	for i := 0; i < 256; i++ {
+		x, y := rng1.Uint64Pair()
+		assert.Equal(t, next[x], y)
+	}
+}
+
+func Test_SafeRNG_Int63Pair(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for i := 0; i < 256; i++ {
+		x, y := rng.Int63Pair()
+		assert.GreaterOrEqual(t, x, int64(0))
+		assert.GreaterOrEqual(t, y, int64(0))
+	}
+}
+
+func Test_SafeRNG_With(t *testing.T) {
+	rng1 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeRandRNG(1) })
+	rng2 := NewUnsafeRandRNG(1)
+	var got []uint64
+	rng1.With(func(r UnsafeRNG) {
+		for i := 0; i < 16; i++ {
+			got = append(got, r.Uint64())
+		}
+	})
+	for _, x := range got {
+		assert.Equal(t, rng2.Uint64(), x)
+	}
+}
+
+func Test_SafeRNG_Acquire(t *testing.T) {
+	rng1 := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeRandRNG(1) }, WithStats())
+	rng2 := NewUnsafeRandRNG(1)
+	r, release := rng1.Acquire()
+	assert.Equal(t, int64(1), rng1.Stats().InUse)
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, rng2.Uint64(), r.Uint64())
+	}
+	release()
+	release()
+	assert.Equal(t, int64(0), rng1.Stats().InUse)
+}
+
+func Benchmark_SyncPoolXoshiro256ssRNG_Uint64Pair_Serial(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	var x, y uint64
+	for i := 0; i < b.N; i++ {
+		x, y = rng.Uint64Pair()
+	}
+	BenchSink = x ^ y
+}
+
+func Benchmark_SyncPoolXoshiro256ssRNG_Acquire_Parallel(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	b.RunParallel(func(pb *testing.PB) {
+		r, release := rng.Acquire()
+		defer release()
+		x := r.Uint64()
+		for pb.Next() {
+			x = r.Uint64()
+		}
+		BenchSink = &x
+	})
+}
@@ -0,0 +1,48 @@
+package fastrand64
+
+import (
+	"runtime"
+	"sync"
+)
+
+const (
+	// parallelFillThreshold is the size above which Read and Bytes split the work across cores
+	parallelFillThreshold = 4 << 20
+	// parallelFillMinShare keeps each goroutine busy long enough to be worth starting
+	parallelFillMinShare = 1 << 20
+)
+
+// fill fills p with random bytes, large buffers are split across up to GOMAXPROCS goroutines, each with
+// its own generator from the pool
+func (s *ThreadsafePoolRNG) fill(p []byte) {
+	if s.stats != nil {
+		s.stats.bytes.Add(uint64(len(p)))
+	}
+	workers := runtime.GOMAXPROCS(0)
+	if max := len(p) / parallelFillMinShare; max < workers {
+		workers = max
+	}
+	if len(p) < parallelFillThreshold || workers < 2 {
+		r, pool := s.get()
+		Bytes(r, p)
+		pool.put(r)
+		return
+	}
+	// whole words per share, the last goroutine takes the remainder
+	share := (len(p) / workers) &^ 7
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		part := p[i*share:]
+		if i < workers-1 {
+			part = part[:share]
+		}
+		go func() {
+			defer wg.Done()
+			r, pool := s.get()
+			Bytes(r, part)
+			pool.put(r)
+		}()
+	}
+	wg.Wait()
+}
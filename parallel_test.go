@@ -0,0 +1,44 @@
+package fastrand64
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_ParallelFill(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	rng := NewSyncPoolXoshiro256ssRNG()
+	n := parallelFillThreshold + 13
+	b := rng.Bytes(n)
+	assert.Equal(t, n, len(b))
+
+	// every share got filled, and by a different generator
+	share := (n / 4) &^ 7
+	zero := make([]byte, 64)
+	for i := 0; i < 4; i++ {
+		assert.False(t, bytes.Equal(zero, b[i*share:i*share+64]))
+		for j := 0; j < i; j++ {
+			assert.False(t, bytes.Equal(b[j*share:j*share+64], b[i*share:i*share+64]))
+		}
+	}
+	assert.False(t, bytes.Equal(zero[:13], b[n-13:]))
+
+	ones := 0
+	for _, x := range rng.Read(b) {
+		ones += int(x & 1)
+	}
+	assert.InDelta(t, n/2, ones, 10000)
+}
+
+func Benchmark_SyncPoolBytes_64MB(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	buf := make([]byte, 64<<20)
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		rng.Read(buf)
+	}
+	BenchSink = &buf
+}
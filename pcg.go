@@ -0,0 +1,11 @@
+package fastrand64
+
+import randv2 "math/rand/v2"
+
+// NewUnsafePCG64RNG creates a new Thread unsafe PCG generator using the golang math/rand/v2 implementation
+//
+// It is a 128 bit LCG with a DXSM output permutation, slower than xoshiro256** but with very well studied output.
+// Both 64 bit halves of the state are expanded from the seed with splitmix64
+func NewUnsafePCG64RNG(seed int64) *randv2.PCG {
+	return randv2.NewPCG(Splitmix64(uint64(seed)), Splitmix64(uint64(seed)+1))
+}
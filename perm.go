@@ -0,0 +1,46 @@
+package fastrand64
+
+// PermInto fills dst with a uniform random permutation of the integers [0..len(dst)) from a thread unsafe RNG,
+// without allocating
+func PermInto(r UnsafeRNG, dst []int) []int {
+	// inside out Fisher-Yates, so dst doesn't need to be initialized first
+	for i := range dst {
+		j := int(Uint64n(r, uint64(i)+1))
+		dst[i] = dst[j]
+		dst[j] = i
+	}
+	return dst
+}
+
+// PermFirstK returns the first k entries of a uniform random permutation of [0..n) from a thread unsafe RNG,
+// in O(k) time and memory however big n is. That is k distinct ints in random order, the same as SampleInts.
+// It panics if k < 0, n < 0 or k > n
+func PermFirstK(r UnsafeRNG, n, k int) []int {
+	if n < 0 || k < 0 || k > n {
+		panic("invalid argument to PermFirstK")
+	}
+	return SampleInts(r, n, k)
+}
+
+// PermFirstK returns the first k entries of a uniform random permutation of [0..n), in O(k). Threadsafe
+//
+// It panics if k < 0, n < 0 or k > n
+func (s *ThreadsafePoolRNG) PermFirstK(n, k int) []int {
+	r, pool := s.get()
+	x := PermFirstK(r, n, k)
+	pool.put(r)
+	return x
+}
+
+// Perm returns a uniform random permutation of the integers [0..n) as a slice, like math/rand.Perm. Threadsafe
+func (s *ThreadsafePoolRNG) Perm(n int) []int {
+	return s.PermInto(make([]int, n))
+}
+
+// PermInto fills dst with a uniform random permutation of the integers [0..len(dst)), without allocating. Threadsafe
+func (s *ThreadsafePoolRNG) PermInto(dst []int) []int {
+	r, pool := s.get()
+	PermInto(r, dst)
+	pool.put(r)
+	return dst
+}
@@ -0,0 +1,62 @@
+package fastrand64
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_Perm(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for _, n := range []int{0, 1, 2, 10, 1000} {
+		p := rng.Perm(n)
+		assert.Equal(t, n, len(p))
+		sort.Ints(p)
+		for i := range p {
+			assert.Equal(t, i, p[i])
+		}
+	}
+}
+
+func Test_PermFirstK(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	p := rng.PermFirstK(math.MaxInt, 5)
+	assert.Len(t, p, 5)
+	seen := map[int]bool{}
+	for _, x := range p {
+		assert.True(t, x >= 0 && x < math.MaxInt)
+		assert.False(t, seen[x])
+		seen[x] = true
+	}
+	all := rng.PermFirstK(10, 10)
+	sort.Ints(all)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, all)
+	assert.Empty(t, PermFirstK(NewUnsafeXoshiro256ssRNG(1), 0, 0))
+	assert.Panics(t, func() { rng.PermFirstK(3, 4) })
+	assert.Panics(t, func() { rng.PermFirstK(-1, 0) })
+}
+
+func Test_PermInto_Uniform(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	counts := map[[3]int]int{}
+	dst := make([]int, 3)
+	for i := 0; i < 60000; i++ {
+		PermInto(rng, dst)
+		counts[[3]int{dst[0], dst[1], dst[2]}]++
+	}
+	assert.Equal(t, 6, len(counts))
+	for _, c := range counts {
+		assert.InDelta(t, 10000, c, 500)
+	}
+}
+
+func Benchmark_SyncPoolXoshiro256ssRNG_PermInto_1024(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	dst := make([]int, 1024)
+	for i := 0; i < b.N; i++ {
+		rng.PermInto(dst)
+	}
+	BenchSink = &dst
+}
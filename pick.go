@@ -0,0 +1,99 @@
+package fastrand64
+
+// Pick returns a uniformly chosen element of s, it panics if s is empty.
+//
+// r can be any UnsafeRNG, a ThreadsafePoolRNG is safe to share between goroutines
+func Pick[T any](r UnsafeRNG, s []T) T {
+	if len(s) == 0 {
+		panic("invalid argument to Pick")
+	}
+	return s[Uint64n(r, uint64(len(s)))]
+}
+
+// PickN returns k distinct elements of s chosen uniformly without replacement, in random order.
+// s is not modified. It panics if k is negative or larger than len(s).
+//
+// r can be any UnsafeRNG. A ThreadsafePoolRNG is safe to share between goroutines, and only has a generator
+// checked out once for the whole selection
+func PickN[T any](r UnsafeRNG, s []T, k int) []T {
+	if k < 0 || k > len(s) {
+		panic("invalid argument to PickN")
+	}
+	if p, ok := r.(*ThreadsafePoolRNG); ok {
+		g, pool := p.get()
+		result := pickN(g, s, k)
+		pool.put(g)
+		return result
+	}
+	return pickN(r, s, k)
+}
+
+func pickN[T any](r UnsafeRNG, s []T, k int) []T {
+	result := make([]T, k)
+	for i, j := range SampleInts(r, len(s), k) {
+		result[i] = s[j]
+	}
+	return result
+}
+
+// PickMapKey returns a uniformly chosen key of m, it panics if m is empty.
+//
+// It walks the map to a random position, so it costs O(len(m)) but allocates nothing. Go's map iteration
+// order is not random enough to just take the first key. For repeated picks from a map that doesn't change,
+// make a MapSnapshot. r can be any UnsafeRNG, a ThreadsafePoolRNG is safe to share between goroutines
+func PickMapKey[K comparable, V any](r UnsafeRNG, m map[K]V) K {
+	k, _ := pickMapEntry("PickMapKey", r, m)
+	return k
+}
+
+// PickMapValue returns the value of a uniformly chosen entry of m, it panics if m is empty. See PickMapKey
+func PickMapValue[K comparable, V any](r UnsafeRNG, m map[K]V) V {
+	_, v := pickMapEntry("PickMapValue", r, m)
+	return v
+}
+
+func pickMapEntry[K comparable, V any](fn string, r UnsafeRNG, m map[K]V) (K, V) {
+	if len(m) == 0 {
+		panic("invalid argument to " + fn)
+	}
+	i := Uint64n(r, uint64(len(m)))
+	for k, v := range m {
+		if i == 0 {
+			return k, v
+		}
+		i--
+	}
+	// unreachable unless m is modified concurrently, which the runtime usually catches anyway
+	panic("fastrand64: map modified during " + fn)
+}
+
+// MapSnapshot copies a map's entries into slices, so picking a random entry is O(1). Later changes to the map
+// are not seen, make a new snapshot for them. It is immutable, so safe to share between goroutines
+type MapSnapshot[K comparable, V any] struct {
+	keys   []K
+	values []V
+}
+
+// NewMapSnapshot copies the entries of m
+func NewMapSnapshot[K comparable, V any](m map[K]V) *MapSnapshot[K, V] {
+	s := &MapSnapshot[K, V]{keys: make([]K, 0, len(m)), values: make([]V, 0, len(m))}
+	for k, v := range m {
+		s.keys = append(s.keys, k)
+		s.values = append(s.values, v)
+	}
+	return s
+}
+
+// Len returns the number of entries in the snapshot
+func (s *MapSnapshot[K, V]) Len() int {
+	return len(s.keys)
+}
+
+// Pick returns a uniformly chosen entry, it panics if the snapshot is empty. r can be any UnsafeRNG
+func (s *MapSnapshot[K, V]) Pick(r UnsafeRNG) (K, V) {
+	if len(s.keys) == 0 {
+		panic("invalid argument to Pick")
+	}
+	i := Uint64n(r, uint64(len(s.keys)))
+	return s.keys[i], s.values[i]
+}
@@ -0,0 +1,89 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Pick(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	s := []string{"a", "b", "c"}
+	counts := map[string]int{}
+	for i := 0; i < 30000; i++ {
+		counts[Pick(rng, s)]++
+	}
+	for _, v := range s {
+		assert.InDelta(t, 10000, counts[v], 500)
+	}
+	assert.Panics(t, func() { Pick(rng, []int{}) })
+}
+
+func Test_PickN(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	s := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	for k := 0; k <= len(s); k++ {
+		picked := PickN(rng, s, k)
+		assert.Equal(t, k, len(picked))
+		seen := map[int]bool{}
+		for _, v := range picked {
+			assert.False(t, seen[v])
+			seen[v] = true
+		}
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, s)
+	assert.Panics(t, func() { PickN(rng, s, 11) })
+	assert.Panics(t, func() { PickN(rng, s, -1) })
+}
+
+func Test_PickN_Uniform(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	counts := make([]int, 5)
+	for i := 0; i < 50000; i++ {
+		for _, v := range PickN(rng, []int{0, 1, 2, 3, 4}, 2) {
+			counts[v]++
+		}
+	}
+	for _, c := range counts {
+		assert.InDelta(t, 20000, c, 600)
+	}
+}
+
+func Test_PickMap(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	keys := map[string]int{}
+	values := map[int]int{}
+	for i := 0; i < 30000; i++ {
+		keys[PickMapKey(rng, m)]++
+		values[PickMapValue(rng, m)]++
+	}
+	for k, v := range m {
+		assert.InDelta(t, 10000, keys[k], 500)
+		assert.InDelta(t, 10000, values[v], 500)
+	}
+	assert.Panics(t, func() { PickMapKey(rng, map[int]int{}) })
+	assert.Panics(t, func() { PickMapValue(rng, map[int]int(nil)) })
+
+	allocs := testing.AllocsPerRun(100, func() { PickMapKey(rng, m) })
+	assert.Equal(t, 0.0, allocs)
+}
+
+func Test_MapSnapshot(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	s := NewMapSnapshot(m)
+	m["d"] = 4
+	assert.Equal(t, 3, s.Len())
+	counts := map[string]int{}
+	for i := 0; i < 30000; i++ {
+		k, v := s.Pick(rng)
+		assert.Equal(t, m[k], v)
+		counts[k]++
+	}
+	assert.Equal(t, 3, len(counts))
+	for _, n := range counts {
+		assert.InDelta(t, 10000, n, 500)
+	}
+	assert.Panics(t, func() { NewMapSnapshot(map[int]int{}).Pick(rng) })
+}
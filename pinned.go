@@ -0,0 +1,64 @@
+package fastrand64
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// pinnedProbes is how many pinned slots get and put look at before falling back to the sync.Pool
+const pinnedProbes = 8
+
+// states of a pinnedSlot
+const (
+	slotEmpty int32 = iota
+	slotBusy        // being filled or emptied
+	slotFull
+)
+
+type pinnedSlotData struct {
+	state atomic.Int32
+	r     UnsafeRNG // only touched by whoever moved state to slotBusy
+}
+
+// pinnedSlot holds one idle generator, padded so neighbouring slots don't share a cache line
+type pinnedSlot struct {
+	pinnedSlotData
+	_ [cacheLine - unsafe.Sizeof(pinnedSlotData{})%cacheLine]byte
+}
+
+// takePinned returns an idle pinned generator, or nil if the slots it looked at were empty
+func (p *rngPools) takePinned() UnsafeRNG {
+	n := len(p.pinned)
+	i := int((stackHash() >> 32) * uint64(n) >> 32)
+	for probe := 0; probe < n && probe < pinnedProbes; probe++ {
+		sl := &p.pinned[i]
+		if sl.state.Load() == slotFull && sl.state.CompareAndSwap(slotFull, slotBusy) {
+			r := sl.r
+			sl.r = nil
+			sl.state.Store(slotEmpty)
+			return r
+		}
+		if i++; i == n {
+			i = 0
+		}
+	}
+	return nil
+}
+
+// pin stores r in an empty pinned slot, and returns false if the slots it looked at were full
+func (p *rngPools) pin(r UnsafeRNG) bool {
+	n := len(p.pinned)
+	i := int((stackHash() >> 32) * uint64(n) >> 32)
+	for probe := 0; probe < n && probe < pinnedProbes; probe++ {
+		sl := &p.pinned[i]
+		if sl.state.Load() == slotEmpty && sl.state.CompareAndSwap(slotEmpty, slotBusy) {
+			sl.r = r
+			sl.state.Store(slotFull)
+			return true
+		}
+		if i++; i == n {
+			i = 0
+		}
+	}
+	return false
+}
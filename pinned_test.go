@@ -0,0 +1,88 @@
+package fastrand64
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithPinned_SurvivesGC(t *testing.T) {
+	rng := NewSyncPoolRNG(nil, WithGenerator("xoshiro256ss"), WithPinned(2), WithStats())
+	for round := 0; round < 3; round++ {
+		for i := 0; i < 100; i++ {
+			rng.Uint64()
+		}
+		// two collections empty a sync.Pool, victim cache and all
+		runtime.GC()
+		runtime.GC()
+	}
+	assert.Equal(t, uint64(1), rng.Stats().Created)
+	assert.Equal(t, int64(0), rng.Stats().InUse)
+}
+
+func Test_WithPinned_Overflow(t *testing.T) {
+	rng := NewSyncPoolRNG(nil, WithGenerator("wyrand"), WithPinned(2), WithPrewarm(4), WithStats())
+	assert.Equal(t, uint64(4), rng.Stats().Created)
+	pools := rng.pools.Load()
+	full := 0
+	for i := range pools.pinned {
+		if pools.pinned[i].state.Load() == slotFull {
+			full++
+		}
+	}
+	assert.Equal(t, 2, full)
+
+	// holding more generators than there are slots spills into the sync.Pool
+	var held []UnsafeRNG
+	for i := 0; i < 5; i++ {
+		r, _ := rng.get()
+		held = append(held, r)
+	}
+	assert.Nil(t, pools.takePinned())
+	for _, r := range held {
+		pools.put(r)
+	}
+	assert.True(t, pools.pinned[0].state.Load() == slotFull && pools.pinned[1].state.Load() == slotFull)
+}
+
+func Test_WithPinned_Reseed(t *testing.T) {
+	rng := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(1) }, WithPinned(0))
+	assert.Equal(t, runtime.GOMAXPROCS(0), rng.pinned)
+	rng.Uint64()
+	rng.InvalidateAndReseed()
+	assert.Len(t, rng.pools.Load().pinned, runtime.GOMAXPROCS(0))
+	assert.NotEqual(t, NewUnsafeXoshiro256ssRNG(1).Uint64(), rng.Uint64())
+
+	_, err := TryNewSyncPoolRNG(nil, WithGenerator("wyrand"), WithPinned(-1))
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+func Test_WithPinned_Concurrent(t *testing.T) {
+	rng := NewSyncPoolRNG(nil, WithGenerator("xoshiro256ss"), WithPinned(4), WithStats())
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10000; i++ {
+				rng.Uint64()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(0), rng.Stats().InUse)
+}
+
+func Benchmark_SyncPoolXoshiro256ssRNG_Uint64_Parallel_Pinned(b *testing.B) {
+	rng := NewSyncPoolRNG(nil, WithGenerator("xoshiro256ss"), WithPinned(0))
+	b.RunParallel(func(pb *testing.PB) {
+		r := rng.Uint64()
+		for pb.Next() {
+			r = rng.Uint64()
+		}
+		BenchSink = &r
+	})
+}
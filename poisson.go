@@ -0,0 +1,69 @@
+package fastrand64
+
+import "math"
+
+// poissonPTRSMin is where Poisson switches from the multiplication method to PTRS,
+// the multiplication method needs about lambda uniforms per value so it only wins for small lambda
+const poissonPTRSMin = 10
+
+// Poisson returns a Poisson distributed int with mean lambda from a thread unsafe RNG
+//
+// Small lambda uses Knuth's multiplication method, larger lambda uses the PTRS transformed rejection method from
+// Hörmann, "The transformed rejection method for generating Poisson random variables" (1993).
+// It panics if lambda is negative, NaN or infinite
+func Poisson(r UnsafeRNG, lambda float64) int {
+	if !(lambda >= 0) || math.IsInf(lambda, 0) {
+		panic("invalid argument to Poisson")
+	}
+	if lambda < poissonPTRSMin {
+		return poissonMult(r, lambda)
+	}
+	return poissonPTRS(r, lambda)
+}
+
+func poissonMult(r UnsafeRNG, lambda float64) int {
+	limit := math.Exp(-lambda)
+	k := 0
+	prod := Float64(r)
+	for prod > limit {
+		k++
+		prod *= Float64(r)
+	}
+	return k
+}
+
+func poissonPTRS(r UnsafeRNG, lambda float64) int {
+	slam := math.Sqrt(lambda)
+	loglam := math.Log(lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invalpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+
+	for {
+		u := Float64(r) - 0.5
+		v := Float64OpenClosed(r)
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return int(k)
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		lg, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invalpha)-math.Log(a/(us*us)+b) <= -lambda+k*loglam-lg {
+			return int(k)
+		}
+	}
+}
+
+// Poisson returns a Poisson distributed int with mean lambda. Threadsafe
+//
+// It panics if lambda is negative, NaN or infinite
+func (s *ThreadsafePoolRNG) Poisson(lambda float64) int {
+	r, pool := s.get()
+	x := Poisson(r, lambda)
+	pool.put(r)
+	return x
+}
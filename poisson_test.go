@@ -0,0 +1,31 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Poisson(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	for _, lambda := range []float64{0.5, 3, 9.9, 10, 42, 1000} {
+		mean, variance := sampleMoments(200000, func() float64 {
+			k := Poisson(rng, lambda)
+			assert.GreaterOrEqual(t, k, 0)
+			return float64(k)
+		})
+		// the standard error of the mean is sqrt(lambda/n)
+		assert.InDelta(t, lambda, mean, 5*math.Sqrt(lambda/200000), "lambda=%v", lambda)
+		assert.InDelta(t, lambda, variance, 0.03*lambda, "lambda=%v", lambda)
+	}
+	assert.Equal(t, 0, Poisson(rng, 0))
+}
+
+func Test_SafeRNG_Poisson(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	mean, _ := sampleMoments(100000, func() float64 { return float64(rng.Poisson(20)) })
+	assert.InDelta(t, 20.0, mean, 0.1)
+	assert.Panics(t, func() { rng.Poisson(-1) })
+	assert.Panics(t, func() { rng.Poisson(math.NaN()) })
+}
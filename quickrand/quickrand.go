@@ -0,0 +1,29 @@
+// Package quickrand drives testing/quick property tests from a fastrand64 pool.
+//
+// It lives apart from fastrand64 because importing testing/quick registers a -quickchecks flag, which
+// has no business showing up in programs that only want random numbers.
+//
+// Example:
+//
+//	rng := fastrand64.NewSyncPoolXoshiro256ssRNG()
+//	err := quick.Check(func(a, b int) bool { return a+b == b+a }, quickrand.Config(rng, 1000))
+package quickrand
+
+import (
+	"reflect"
+	"testing/quick"
+
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+// Config returns a quick.Config generating values from the pool. maxCount of 0 keeps quick's default,
+// 100 or the -quickchecks flag
+func Config(rng *fastrand64.ThreadsafePoolRNG, maxCount int) *quick.Config {
+	return &quick.Config{Rand: fastrand64.AsRand(rng), MaxCount: maxCount}
+}
+
+// Value returns an arbitrary value of type t from the pool, the way quick.Check generates arguments.
+// ok is false if t can't be generated, like a func or a chan
+func Value(rng *fastrand64.ThreadsafePoolRNG, t reflect.Type) (value reflect.Value, ok bool) {
+	return quick.Value(t, fastrand64.AsRand(rng))
+}
@@ -0,0 +1,40 @@
+package quickrand
+
+import (
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+func Test_Config(t *testing.T) {
+	rng := fastrand64.NewSyncPoolXoshiro256ssRNG()
+	calls := 0
+	err := quick.Check(func(a, b int) bool {
+		calls++
+		return a+b == b+a
+	}, Config(rng, 50))
+	assert.NoError(t, err)
+	assert.Equal(t, 50, calls)
+
+	// a false property is found
+	err = quick.Check(func(a uint8) bool { return a < 200 }, Config(rng, 1000))
+	assert.Error(t, err)
+}
+
+func Test_Value(t *testing.T) {
+	rng := fastrand64.NewSyncPoolXoshiro256ssRNG()
+	type pair struct {
+		A int
+		B []string
+	}
+	v, ok := Value(rng, reflect.TypeOf(pair{}))
+	assert.True(t, ok)
+	_, ok = v.Interface().(pair)
+	assert.True(t, ok)
+
+	_, ok = Value(rng, reflect.TypeOf(func() {}))
+	assert.False(t, ok)
+}
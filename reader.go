@@ -0,0 +1,44 @@
+package fastrand64
+
+import "io"
+
+// defaultReaderSize is the NewReader buffer size when none is given
+const defaultReaderSize = 4096
+
+// randReader is the io.Reader returned by NewReader
+type randReader struct {
+	pool *ThreadsafePoolRNG
+	buf  []byte
+	off  int // buf[off:] is still unread
+}
+
+// NewReader returns an io.Reader of random bytes that refills a bufSize buffer from the pool and serves small
+// reads from it, so code that reads a few bytes at a time doesn't check out a generator on every call.
+// bufSize <= 0 means 4096. Reads never fail, and reads of at least bufSize bytes skip the buffer.
+//
+// Like a bufio.Reader it is not safe for concurrent use, give each goroutine its own
+func NewReader(pool *ThreadsafePoolRNG, bufSize int) io.Reader {
+	if bufSize <= 0 {
+		bufSize = defaultReaderSize
+	}
+	r := &randReader{pool: pool, buf: make([]byte, bufSize)}
+	r.off = len(r.buf)
+	return r
+}
+
+// Read fills p with random bytes, it always returns len(p), nil
+func (r *randReader) Read(p []byte) (int, error) {
+	n := copy(p, r.buf[r.off:])
+	r.off += n
+	rest := p[n:]
+	if len(rest) == 0 {
+		return n, nil
+	}
+	if len(rest) >= len(r.buf) {
+		r.pool.fill(rest)
+		return len(p), nil
+	}
+	r.pool.fill(r.buf)
+	r.off = copy(rest, r.buf)
+	return len(p), nil
+}
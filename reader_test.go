@@ -0,0 +1,69 @@
+package fastrand64
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewReader(t *testing.T) {
+	pool := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(1) }, WithStats())
+	rd := NewReader(pool, 64)
+
+	// small reads are carved out of one buffer, in the generator's byte order
+	ref := NewUnsafeXoshiro256ssRNG(1)
+	expected := Bytes(ref, make([]byte, 64))
+	var got []byte
+	for i := 0; i < 16; i++ {
+		p := make([]byte, 4)
+		n, err := rd.Read(p)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, n)
+		got = append(got, p...)
+	}
+	assert.Equal(t, expected, got)
+	assert.Equal(t, uint64(1), pool.Stats().Gets)
+
+	// a read straddling the end of the buffer gets the rest of it and then a fresh one. Which generator fills
+	// it is up to sync.Pool, so compare against the buffer rather than the reference stream
+	buf := rd.(*randReader).buf
+	rd.Read(make([]byte, 60))
+	rest := append([]byte(nil), buf[60:]...)
+	p := make([]byte, 8)
+	n, err := rd.Read(p)
+	assert.NoError(t, err)
+	assert.Equal(t, 8, n)
+	assert.Equal(t, rest, p[:4])
+	assert.Equal(t, buf[:4], p[4:])
+	assert.Equal(t, uint64(3), pool.Stats().Gets)
+
+	// big reads skip the buffer
+	p = make([]byte, 256)
+	n, _ = rd.Read(p)
+	assert.Equal(t, 256, n)
+	assert.NotEqual(t, make([]byte, 256), p[4:])
+	assert.Equal(t, uint64(4), pool.Stats().Gets)
+
+	n, err = rd.Read(nil)
+	assert.Equal(t, 0, n)
+	assert.NoError(t, err)
+}
+
+func Test_NewReader_ReadFull(t *testing.T) {
+	rd := NewReader(NewSyncPoolXoshiro256ssRNG(), 0)
+	p := make([]byte, 10000)
+	n, err := io.ReadFull(rd, p)
+	assert.NoError(t, err)
+	assert.Equal(t, 10000, n)
+	assert.Equal(t, defaultReaderSize, len(rd.(*randReader).buf))
+}
+
+func Benchmark_NewReader_Read4(b *testing.B) {
+	rd := NewReader(NewSyncPoolXoshiro256ssRNG(), 0)
+	p := make([]byte, 4)
+	for i := 0; i < b.N; i++ {
+		rd.Read(p)
+	}
+	BenchSink = &p
+}
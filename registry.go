@@ -0,0 +1,70 @@
+package fastrand64
+
+import (
+	"sort"
+	"sync"
+)
+
+// GeneratorFactory creates a thread unsafe generator from a seed, see Register
+type GeneratorFactory func(seed int64) UnsafeRNG
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]GeneratorFactory{}
+)
+
+func init() {
+	for _, c := range generatorCandidates {
+		registry[c.name] = c.newFn
+	}
+	registry["xoroshiro128p"] = func(seed int64) UnsafeRNG { return NewUnsafeXoroshiro128pRNG(seed) }
+	registry["xoroshiro128ss"] = func(seed int64) UnsafeRNG { return NewUnsafeXoroshiro128ssRNG(seed) }
+	registry["pcg64"] = func(seed int64) UnsafeRNG { return NewUnsafePCG64RNG(seed) }
+	registry["rand"] = func(seed int64) UnsafeRNG { return NewUnsafeRandRNG(seed) }
+}
+
+// Register makes a generator available by name to New, WithGenerator and FASTRAND_ALGO, so applications can
+// pick one from configuration and plug in their own. Call it from an init func.
+//
+// It panics if name is empty, factory is nil, or name is already registered
+func Register(name string, factory GeneratorFactory) {
+	if name == "" || factory == nil {
+		panic("invalid argument to Register")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[name]; ok {
+		panic("fastrand64: Register called twice for generator " + name)
+	}
+	registry[name] = factory
+}
+
+// New creates a thread unsafe generator by its registered name. Built in names are "xoshiro256ss",
+// "xoshiro256ssx4", "xoroshiro128p", "xoroshiro128ss", "wyrand", "chacha8", "aesctr", "lehmer128", "pcg64" and
+// "rand" (math/rand)
+func New(name string, seed int64) (UnsafeRNG, error) {
+	factory, ok := lookupGenerator(name)
+	if !ok {
+		return nil, invalidArgument("New %q: unknown generator", name)
+	}
+	return factory(seed), nil
+}
+
+// Generators returns the registered generator names, sorted
+func Generators() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupGenerator(name string) (GeneratorFactory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	factory, ok := registry[name]
+	return factory, ok
+}
@@ -0,0 +1,52 @@
+package fastrand64
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Registry_Builtins(t *testing.T) {
+	for _, name := range []string{"xoshiro256ss", "xoshiro256ssx4", "wyrand", "chacha8", "aesctr", "lehmer128", "xoroshiro128p", "xoroshiro128ss", "pcg64", "rand"} {
+		assert.Contains(t, Generators(), name)
+		a, err := New(name, 42)
+		assert.NoError(t, err, name)
+		b, _ := New(name, 42)
+		c, _ := New(name, 43)
+		x := a.Uint64()
+		assert.Equal(t, x, b.Uint64(), name)
+		assert.NotEqual(t, x, c.Uint64(), name)
+	}
+	r, err := New("nope", 1)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	assert.Nil(t, r)
+}
+
+func Test_Registry_Register(t *testing.T) {
+	Register("test-counter", func(seed int64) UnsafeRNG { return &incrementRNG{n: uint64(seed)} })
+	r, err := New("test-counter", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), r.Uint64())
+
+	// registered generators can back pools too
+	t.Setenv(EnvAlgo, "test-counter")
+	t.Setenv(EnvSeed, "")
+	t.Setenv(EnvDeterministic, "")
+	s, err := NewPoolFromEnv()
+	assert.NoError(t, err)
+	assert.NotNil(t, s)
+	s = NewSyncPoolRNG(nil, WithGenerator("test-counter"))
+	assert.NotNil(t, s)
+
+	assert.Panics(t, func() { Register("test-counter", func(seed int64) UnsafeRNG { return nil }) })
+	assert.Panics(t, func() { Register("", func(seed int64) UnsafeRNG { return nil }) })
+	assert.Panics(t, func() { Register("test-nil", nil) })
+}
+
+type incrementRNG struct{ n uint64 }
+
+func (r *incrementRNG) Uint64() uint64 {
+	r.n++
+	return r.n
+}
@@ -0,0 +1,173 @@
+package fastrand64
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// restoreClockSkew is how far the wall clock may run ahead of the monotonic clock before ReseedIfRestored
+// assumes the process was frozen and thawed, a restored VM resyncs its wall clock but not its monotonic one
+const restoreClockSkew = time.Second
+
+// freshSeed returns a seed that differs between a checkpointed process and each of its restored copies,
+// unlike math/rand whose global state is restored along with everything else
+func freshSeed() uint64 {
+	var b [8]byte
+	if _, err := cryptorand.Read(b[:]); err == nil {
+		return binary.LittleEndian.Uint64(b[:])
+	}
+	return Splitmix64(uint64(time.Now().UnixNano()) ^ uint64(os.Getpid())<<32)
+}
+
+// reseedFresh reseeds r from freshSeed if it knows how to be seeded
+func reseedFresh(r UnsafeRNG) {
+	reseedWith(r, freshSeed)
+}
+
+// reseedWith reseeds r with seeds from next if it knows how to be seeded
+func reseedWith(r UnsafeRNG, next func() uint64) {
+	switch g := r.(type) {
+	case interface{ Seed(seed int64) }:
+		g.Seed(int64(next()))
+	case interface{ Seed(seed [32]byte) }:
+		var key [32]byte
+		for i := 0; i < 4; i++ {
+			binary.LittleEndian.PutUint64(key[i*8:], next())
+		}
+		g.Seed(key)
+	}
+}
+
+// InvalidateAndReseed throws away every generator and buffered bit held by the pool, so nothing drawn after it
+// can repeat a stream from before it. Generators checked out at the time are dropped when they come back.
+//
+// Replacement generators still come from the pool's constructor func, but the constructor's seed often comes from
+// state that was itself checkpointed, so any generator with a Seed(int64) or Seed([32]byte) method is then reseeded
+// from crypto/rand. Call this after fork or checkpoint/restore (CRIU, Lambda SnapStart style), or see ReseedIfRestored
+func (s *ThreadsafePoolRNG) InvalidateAndReseed() {
+	fn := s.fn
+	s.pools.Store(s.newPools(func() UnsafeRNG {
+		r := fn()
+		reseedFresh(r)
+		return r
+	}))
+	if s.stats != nil {
+		s.stats.reseeds.Add(1)
+	}
+	s.mark.Store(currentProcessMark())
+}
+
+// ReseedAll throws away every generator and buffered bit held by the pool, like InvalidateAndReseed, but seeds the
+// replacements deterministically from seed: the first generator the pool creates afterwards gets seeds derived
+// from (seed, 0), the next from (seed, 1), and so on. Generators without a Seed(int64) or Seed([32]byte) method
+// keep whatever seed the pool's constructor gave them.
+//
+// Only each generator's stream is repeatable, not the pool's. Which generator serves which call depends on
+// goroutine scheduling, and sync.Pool may drop idle generators at any GC so later ones get new indexes. A single
+// goroutine making the same calls usually sees the same values, but don't build tests on it, use an unsafe
+// generator or NewSyncPoolXoshiro256ssRNGFromSeed and a fixed schedule for that
+func (s *ThreadsafePoolRNG) ReseedAll(seed int64) {
+	fn := s.fn
+	var instances atomic.Uint64
+	s.pools.Store(s.newPools(func() UnsafeRNG {
+		r := fn()
+		base := Uint64At(uint64(seed), instances.Add(1)-1)
+		var i uint64
+		reseedWith(r, func() uint64 {
+			i++
+			return Uint64At(base, i-1)
+		})
+		return r
+	}))
+	if s.stats != nil {
+		s.stats.reseeds.Add(1)
+	}
+	s.mark.Store(currentProcessMark())
+}
+
+// processMark identifies the running process, so a restored copy can tell it isn't the original
+type processMark struct {
+	pid       int
+	startTime string
+	wall      int64     // unix nanos
+	mono      time.Time // only its monotonic reading is used
+}
+
+func currentProcessMark() *processMark {
+	now := time.Now()
+	return &processMark{pid: os.Getpid(), startTime: processStartTime(), wall: now.UnixNano(), mono: now}
+}
+
+// processStartTime returns the start time field of /proc/self/stat, or "" where there is no procfs
+func processStartTime() string {
+	b, err := os.ReadFile("/proc/self/stat")
+	if err != nil {
+		return ""
+	}
+	// the command name can contain spaces, so count fields from the closing paren
+	s := string(b)
+	i := strings.LastIndexByte(s, ')')
+	if i < 0 {
+		return ""
+	}
+	fields := strings.Fields(s[i+1:])
+	// starttime is field 22 overall, and the fields after the paren start at field 3
+	if len(fields) < 20 {
+		return ""
+	}
+	return fields[19]
+}
+
+// restored reports whether the process looks like a fork or a restored checkpoint of the one that made m
+func (m *processMark) restored(now *processMark) bool {
+	if now.pid != m.pid || now.startTime != m.startTime {
+		return true
+	}
+	wall := time.Duration(now.wall - m.wall)
+	mono := now.mono.Sub(m.mono)
+	return wall-mono > restoreClockSkew
+}
+
+// ReseedIfRestored checks whether the process has been forked or restored from a checkpoint since the pool was made
+// (or last reseeded), and if so calls InvalidateAndReseed and returns true.
+//
+// The checks are heuristics: a changed pid, a changed process start time, or the wall clock jumping ahead of the
+// monotonic clock. Call it from a restore hook if the platform has one, otherwise see WatchForRestore
+func (s *ThreadsafePoolRNG) ReseedIfRestored() bool {
+	now := currentProcessMark()
+	m := s.mark.Load()
+	if !m.restored(now) {
+		// keep the clock comparison short so slow drift doesn't add up to a false alarm
+		s.mark.CompareAndSwap(m, now)
+		return false
+	}
+	s.InvalidateAndReseed()
+	return true
+}
+
+// WatchForRestore calls ReseedIfRestored every interval from a background goroutine, and onRestore (if not nil)
+// whenever it reseeds. Call the returned func to stop watching, calling it again does nothing
+func (s *ThreadsafePoolRNG) WatchForRestore(interval time.Duration, onRestore func()) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if s.ReseedIfRestored() && onRestore != nil {
+					onRestore()
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
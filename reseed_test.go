@@ -0,0 +1,103 @@
+package fastrand64
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_InvalidateAndReseed(t *testing.T) {
+	// every generator the constructor makes is identical, like a checkpoint restored twice
+	rng := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(1) })
+	before := rng.Uint64()
+	assert.Equal(t, NewUnsafeXoshiro256ssRNG(1).Uint64(), before)
+	rng.Bool()
+
+	rng.InvalidateAndReseed()
+	assert.NotEqual(t, NewUnsafeXoshiro256ssRNG(1).Uint64(), rng.Uint64())
+
+	chacha := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeChaCha8RNG(1) })
+	chacha.InvalidateAndReseed()
+	assert.NotEqual(t, NewUnsafeChaCha8RNG(1).Uint64(), chacha.Uint64())
+}
+
+func Test_SafeRNG_ReseedAll(t *testing.T) {
+	for _, fn := range []func() UnsafeRNG{
+		func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(1) },
+		func() UnsafeRNG { return NewUnsafeChaCha8RNG(1) },
+	} {
+		a := NewSyncPoolRNG(fn, WithStats())
+		b := NewSyncPoolRNG(fn)
+		a.ReseedAll(42)
+		b.ReseedAll(42)
+		assert.Equal(t, uint64(1), a.Stats().Reseeds)
+
+		// generators are seeded by the order they are created in, so holding two gives two different streams
+		a0, aPool := a.get()
+		a1, _ := a.get()
+		b0, bPool := b.get()
+		x := a0.Uint64()
+		assert.Equal(t, x, b0.Uint64())
+		assert.NotEqual(t, x, a1.Uint64())
+		assert.NotEqual(t, fn().Uint64(), x)
+		aPool.put(a0)
+		aPool.put(a1)
+		bPool.put(b0)
+
+		b.ReseedAll(43)
+		b0, bPool = b.get()
+		assert.NotEqual(t, x, b0.Uint64())
+		bPool.put(b0)
+	}
+
+	// a *rand.Rand over the pool can be seeded now
+	r := AsRand(NewSyncPoolXoshiro256ssRNG())
+	r.Seed(7)
+	assert.Less(t, r.Intn(10), 10)
+}
+
+func Test_processMark_restored(t *testing.T) {
+	m := currentProcessMark()
+	assert.NotEmpty(t, m.startTime)
+	assert.False(t, m.restored(currentProcessMark()))
+
+	forked := currentProcessMark()
+	forked.pid++
+	assert.True(t, m.restored(forked))
+
+	// the wall clock jumped an hour while the monotonic clock didn't move
+	thawed := *m
+	thawed.wall += int64(time.Hour)
+	assert.True(t, m.restored(&thawed))
+}
+
+func Test_SafeRNG_ReseedIfRestored(t *testing.T) {
+	rng := NewSyncPoolRNG(func() UnsafeRNG { return NewUnsafeXoshiro256ssRNG(1) })
+	assert.False(t, rng.ReseedIfRestored())
+
+	m := *rng.mark.Load()
+	m.pid++
+	rng.mark.Store(&m)
+	assert.True(t, rng.ReseedIfRestored())
+	assert.NotEqual(t, NewUnsafeXoshiro256ssRNG(1).Uint64(), rng.Uint64())
+	assert.False(t, rng.ReseedIfRestored())
+}
+
+func Test_SafeRNG_WatchForRestore(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	m := *rng.mark.Load()
+	m.startTime = "restored"
+	rng.mark.Store(&m)
+
+	restored := make(chan struct{}, 1)
+	stop := rng.WatchForRestore(time.Millisecond, func() { restored <- struct{}{} })
+	defer stop()
+	select {
+	case <-restored:
+	case <-time.After(5 * time.Second):
+		t.Fatal("restore was not detected")
+	}
+	stop()
+	assert.NotPanics(t, stop)
+}
@@ -0,0 +1,188 @@
+package fastrand64
+
+import (
+	"bufio"
+	"container/heap"
+	"io"
+	"iter"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// WeightedReservoir picks k items from a stream of unknown length, each with probability proportional to its
+// weight, using the A-Res algorithm from Efraimidis & Spirakis, "Weighted random sampling with a reservoir" (2006).
+//
+// It is safe to Offer from multiple goroutines
+type WeightedReservoir[T any] struct {
+	rng  *ThreadsafePoolRNG
+	k    int
+	mu   sync.Mutex
+	heap reservoirHeap[T]
+}
+
+type reservoirEntry[T any] struct {
+	item T
+	key  float64
+}
+
+// reservoirHeap is a min heap on key, so the weakest entry is the one to evict
+type reservoirHeap[T any] []reservoirEntry[T]
+
+func (h reservoirHeap[T]) Len() int            { return len(h) }
+func (h reservoirHeap[T]) Less(i, j int) bool  { return h[i].key < h[j].key }
+func (h reservoirHeap[T]) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *reservoirHeap[T]) Push(x interface{}) { *h = append(*h, x.(reservoirEntry[T])) }
+func (h *reservoirHeap[T]) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// NewWeightedReservoir creates a reservoir keeping up to k items, drawing from rng
+func NewWeightedReservoir[T any](rng *ThreadsafePoolRNG, k int) (*WeightedReservoir[T], error) {
+	if rng == nil {
+		return nil, invalidArgument("NewWeightedReservoir: nil rng")
+	}
+	if k <= 0 {
+		return nil, invalidArgument("NewWeightedReservoir: k must be positive, got %d", k)
+	}
+	return &WeightedReservoir[T]{rng: rng, k: k, heap: make(reservoirHeap[T], 0, k)}, nil
+}
+
+// Offer considers an item for the reservoir. Items with zero weight are never kept. Threadsafe
+//
+// It panics if weight is negative, NaN or infinite
+func (w *WeightedReservoir[T]) Offer(item T, weight float64) {
+	if !(weight >= 0) || math.IsInf(weight, 0) {
+		panic("invalid argument to Offer")
+	}
+	if weight == 0 {
+		return
+	}
+	// log(u)/w orders the same as the paper's u^(1/w) but doesn't underflow for small weights
+	key := math.Log(w.rng.Float64OpenClosed()) / weight
+
+	w.mu.Lock()
+	if len(w.heap) < w.k {
+		heap.Push(&w.heap, reservoirEntry[T]{item: item, key: key})
+	} else if key > w.heap[0].key {
+		w.heap[0] = reservoirEntry[T]{item: item, key: key}
+		heap.Fix(&w.heap, 0)
+	}
+	w.mu.Unlock()
+}
+
+// Result returns a copy of the items currently in the reservoir, the most strongly selected first. Threadsafe
+func (w *WeightedReservoir[T]) Result() []T {
+	w.mu.Lock()
+	entries := make([]reservoirEntry[T], len(w.heap))
+	copy(entries, w.heap)
+	w.mu.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key > entries[j].key })
+	result := make([]T, len(entries))
+	for i, e := range entries {
+		result[i] = e.item
+	}
+	return result
+}
+
+// reservoirL keeps k uniformly chosen items from a stream, using Algorithm L from Li, "Reservoir-sampling
+// algorithms of time complexity O(n(1+log(N/n)))" (1994). Rather than drawing for every item it draws how many
+// items to skip, so a long stream costs O(k log(n/k)) draws
+type reservoirL[T any] struct {
+	r     UnsafeRNG
+	k     int
+	items []T
+	w     float64
+	next  uint64 // index of the next item to go into the reservoir
+	seen  uint64
+}
+
+func newReservoirL[T any](r UnsafeRNG, k int) *reservoirL[T] {
+	return &reservoirL[T]{r: r, k: k, items: make([]T, 0, k)}
+}
+
+func (l *reservoirL[T]) offer(item T) {
+	i := l.seen
+	l.seen++
+	if len(l.items) < l.k {
+		l.items = append(l.items, item)
+		if len(l.items) == l.k {
+			l.w = math.Exp(math.Log(Float64Open(l.r)) / float64(l.k))
+			l.skip()
+		}
+		return
+	}
+	if i != l.next {
+		return
+	}
+	l.items[Uint64n(l.r, uint64(l.k))] = item
+	l.w *= math.Exp(math.Log(Float64Open(l.r)) / float64(l.k))
+	l.skip()
+}
+
+func (l *reservoirL[T]) skip() {
+	// log1p keeps the precision when w is tiny, deep into a long stream
+	gap := math.Floor(math.Log(Float64Open(l.r)) / math.Log1p(-l.w))
+	if gap >= math.MaxUint64/2 {
+		l.next = math.MaxUint64
+		return
+	}
+	l.next = l.seen + uint64(gap)
+}
+
+// SampleSeq returns k items chosen uniformly from seq without replacement, or all of them if seq yields fewer,
+// reading seq once without knowing its length in advance. The items come back in no particular order.
+//
+// r can be any UnsafeRNG, a ThreadsafePoolRNG only has a generator checked out once for the whole sequence.
+// It panics if k < 0
+func SampleSeq[T any](r UnsafeRNG, seq iter.Seq[T], k int) []T {
+	if k < 0 {
+		panic("invalid argument to SampleSeq")
+	}
+	if p, ok := r.(*ThreadsafePoolRNG); ok {
+		g, pool := p.get()
+		defer pool.put(g)
+		r = g
+	}
+	l := newReservoirL[T](r, k)
+	if k == 0 {
+		return l.items
+	}
+	for item := range seq {
+		l.offer(item)
+	}
+	return l.items
+}
+
+// SampleLines returns k lines chosen uniformly from rd, or all of them if there are fewer, reading rd to the end
+// once. Lines don't include their line ending, "\n" or "\r\n", and come back in no particular order.
+// Threadsafe
+//
+// An error is returned if k < 0 or reading fails, along with the lines sampled so far
+func (s *ThreadsafePoolRNG) SampleLines(rd io.Reader, k int) ([]string, error) {
+	if k < 0 {
+		return nil, invalidArgument("SampleLines: k must not be negative, got %d", k)
+	}
+	r, pool := s.get()
+	defer pool.put(r)
+	l := newReservoirL[string](r, k)
+	br := bufio.NewReader(rd)
+	for {
+		line, err := br.ReadString('\n')
+		if line != "" && k > 0 {
+			line = strings.TrimSuffix(line, "\n")
+			l.offer(strings.TrimSuffix(line, "\r"))
+		}
+		if err == io.EOF {
+			return l.items, nil
+		}
+		if err != nil {
+			return l.items, err
+		}
+	}
+}
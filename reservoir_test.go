@@ -0,0 +1,130 @@
+package fastrand64
+
+import (
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewWeightedReservoir_Errors(t *testing.T) {
+	r, err := NewWeightedReservoir[int](nil, 1)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	assert.Nil(t, r)
+	r, err = NewWeightedReservoir[int](NewSyncPoolXoshiro256ssRNG(), 0)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	assert.Nil(t, r)
+}
+
+func Test_WeightedReservoir(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	r, err := NewWeightedReservoir[int](rng, 3)
+	assert.NoError(t, err)
+	assert.Empty(t, r.Result())
+	r.Offer(1, 1)
+	r.Offer(2, 0)
+	assert.Equal(t, []int{1}, r.Result())
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				r.Offer(g*1000+i, 1)
+			}
+		}(g)
+	}
+	wg.Wait()
+	assert.Equal(t, 3, len(r.Result()))
+	assert.Panics(t, func() { r.Offer(0, -1) })
+}
+
+func Test_WeightedReservoir_Weights(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	counts := make([]int, 3)
+	for trial := 0; trial < 20000; trial++ {
+		r, _ := NewWeightedReservoir[int](rng, 1)
+		r.Offer(0, 1)
+		r.Offer(1, 2)
+		r.Offer(2, 7)
+		counts[r.Result()[0]]++
+	}
+	assert.InDelta(t, 0.1, float64(counts[0])/20000, 0.01)
+	assert.InDelta(t, 0.2, float64(counts[1])/20000, 0.01)
+	assert.InDelta(t, 0.7, float64(counts[2])/20000, 0.01)
+}
+
+func Test_SampleSeq_Uniform(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	for _, n := range []int{20, 1000} {
+		counts := make([]int, n)
+		const trials = 20000
+		for i := 0; i < trials; i++ {
+			for _, v := range SampleSeq(rng, func(yield func(int) bool) {
+				for j := 0; j < n; j++ {
+					if !yield(j) {
+						return
+					}
+				}
+			}, 5) {
+				counts[v]++
+			}
+		}
+		want := float64(trials) * 5 / float64(n)
+		total := 0
+		for _, c := range counts {
+			total += c
+			assert.InDelta(t, want, c, 6*math.Sqrt(want)+1, "n=%d", n)
+		}
+		assert.Equal(t, trials*5, total)
+	}
+}
+
+func Test_SampleSeq(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	short := SampleSeq(rng, slices.Values([]int{1, 2, 3}), 5)
+	sort.Ints(short)
+	assert.Equal(t, []int{1, 2, 3}, short)
+	assert.Equal(t, 0, len(SampleSeq(rng, slices.Values([]int{1, 2, 3}), 0)))
+
+	// a long stream keeps distinct items and doesn't draw for each one
+	picked := SampleSeq[int](rng, func(yield func(int) bool) {
+		for i := 0; i < 1000000; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}, 10)
+	assert.Equal(t, 10, len(picked))
+	assert.Equal(t, 10, len(slices.Compact(slices.Sorted(slices.Values(picked)))))
+	assert.Panics(t, func() { SampleSeq(rng, slices.Values([]int{1}), -1) })
+}
+
+func Test_SampleLines(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	lines, err := rng.SampleLines(strings.NewReader("a\r\nb\n\nc"), 10)
+	assert.NoError(t, err)
+	sort.Strings(lines)
+	assert.Equal(t, []string{"", "a", "b", "c"}, lines)
+
+	var b strings.Builder
+	for i := 0; i < 1000; i++ {
+		b.WriteString("line\n")
+	}
+	lines, err = rng.SampleLines(strings.NewReader(b.String()), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"line", "line", "line"}, lines)
+
+	_, err = rng.SampleLines(iotest.ErrReader(io.ErrUnexpectedEOF), 3)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	_, err = rng.SampleLines(strings.NewReader(""), -1)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+}
@@ -0,0 +1,104 @@
+package fastrand64
+
+import (
+	"math"
+	"time"
+)
+
+// RetryPolicy describes an exponential backoff with jitter
+type RetryPolicy struct {
+	// Attempts is the number of retries to plan
+	Attempts int
+	// Base is the backoff before the first retry, it grows by Multiplier each attempt
+	Base time.Duration
+	// Max caps the backoff of any one attempt, 0 means no cap
+	Max time.Duration
+	// Multiplier is the growth factor of the backoff, at least 1. 0 means 2
+	Multiplier float64
+	// Jitter is the fraction of each backoff that is randomized, in [0, 1]. The delay is drawn uniformly from
+	// [backoff*(1-Jitter), backoff], so 1 is "full jitter" and 0.5 "equal jitter"
+	Jitter float64
+}
+
+// RetryAttempt is one planned retry
+type RetryAttempt struct {
+	Attempt int           // counting from 0
+	Backoff time.Duration // the backoff before jitter
+	Delay   time.Duration // how long to wait before this attempt, after jitter
+	At      time.Duration // when this attempt starts, the sum of the delays so far
+}
+
+// RetryPlanner precomputes a whole jittered retry schedule from a seed, so backoff code can be driven by it
+// in tests and its behaviour asserted against exact timings. Safe for concurrent reads
+type RetryPlanner struct {
+	schedule []RetryAttempt
+}
+
+// NewRetryPlanner plans the retries of policy, with the jitter drawn from a generator seeded by seed
+func NewRetryPlanner(policy RetryPolicy, seed Seed) (*RetryPlanner, error) {
+	if policy.Attempts < 0 {
+		return nil, invalidArgument("NewRetryPlanner: %d attempts", policy.Attempts)
+	}
+	if policy.Base < 0 || policy.Max < 0 {
+		return nil, invalidArgument("NewRetryPlanner: negative backoff")
+	}
+	m := policy.Multiplier
+	if m == 0 {
+		m = 2
+	}
+	if !(m >= 1) || math.IsInf(m, 0) {
+		return nil, invalidArgument("NewRetryPlanner: multiplier %v", policy.Multiplier)
+	}
+	if !(policy.Jitter >= 0 && policy.Jitter <= 1) {
+		return nil, invalidArgument("NewRetryPlanner: jitter %v not in [0, 1]", policy.Jitter)
+	}
+	r := NewUnsafeXoshiro256ssRNGFromSeed(seed)
+	p := &RetryPlanner{schedule: make([]RetryAttempt, policy.Attempts)}
+	limit := float64(math.MaxInt64)
+	if policy.Max > 0 {
+		limit = float64(policy.Max)
+	}
+	backoff := float64(policy.Base)
+	var at time.Duration
+	for i := range p.schedule {
+		b := math.Min(backoff, limit)
+		delay := saturatedDuration(b * (1 - policy.Jitter*Float64(r)))
+		if at > math.MaxInt64-delay {
+			at = math.MaxInt64
+		} else {
+			at += delay
+		}
+		p.schedule[i] = RetryAttempt{Attempt: i, Backoff: saturatedDuration(b), Delay: delay, At: at}
+		// stop growing once past the cap, so a long schedule can't overflow
+		if backoff < limit {
+			backoff *= m
+		}
+	}
+	return p, nil
+}
+
+// saturatedDuration converts nanoseconds to a Duration, clamping values too big for one
+func saturatedDuration(ns float64) time.Duration {
+	if ns >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(ns)
+}
+
+// Schedule returns a copy of the whole plan
+func (p *RetryPlanner) Schedule() []RetryAttempt {
+	return append([]RetryAttempt(nil), p.schedule...)
+}
+
+// Delay returns how long to wait before attempt, and false once the planned attempts are used up
+func (p *RetryPlanner) Delay(attempt int) (time.Duration, bool) {
+	if attempt < 0 || attempt >= len(p.schedule) {
+		return 0, false
+	}
+	return p.schedule[attempt].Delay, true
+}
+
+// Len returns the number of planned attempts
+func (p *RetryPlanner) Len() int {
+	return len(p.schedule)
+}
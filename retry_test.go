@@ -0,0 +1,82 @@
+package fastrand64
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewRetryPlanner_Errors(t *testing.T) {
+	seed := SeedFromString(t.Name())
+	for _, p := range []RetryPolicy{
+		{Attempts: -1},
+		{Attempts: 1, Base: -time.Second},
+		{Attempts: 1, Multiplier: 0.5},
+		{Attempts: 1, Jitter: 2},
+	} {
+		r, err := NewRetryPlanner(p, seed)
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+		assert.Nil(t, r)
+	}
+}
+
+func Test_RetryPlanner_NoJitter(t *testing.T) {
+	p, err := NewRetryPlanner(RetryPolicy{Attempts: 5, Base: 100 * time.Millisecond, Max: time.Second}, SeedFromString(t.Name()))
+	assert.NoError(t, err)
+	assert.Equal(t, []RetryAttempt{
+		{0, 100 * time.Millisecond, 100 * time.Millisecond, 100 * time.Millisecond},
+		{1, 200 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond},
+		{2, 400 * time.Millisecond, 400 * time.Millisecond, 700 * time.Millisecond},
+		{3, 800 * time.Millisecond, 800 * time.Millisecond, 1500 * time.Millisecond},
+		{4, time.Second, time.Second, 2500 * time.Millisecond},
+	}, p.Schedule())
+	d, ok := p.Delay(4)
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, d)
+	_, ok = p.Delay(5)
+	assert.False(t, ok)
+}
+
+func Test_RetryPlanner_Jitter(t *testing.T) {
+	policy := RetryPolicy{Attempts: 100, Base: time.Millisecond, Max: time.Minute, Multiplier: 1.5, Jitter: 0.5}
+	a, _ := NewRetryPlanner(policy, SeedFromString("a"))
+	again, _ := NewRetryPlanner(policy, SeedFromString("a"))
+	b, _ := NewRetryPlanner(policy, SeedFromString("b"))
+	assert.Equal(t, a.Schedule(), again.Schedule())
+	assert.NotEqual(t, a.Schedule(), b.Schedule())
+	assert.Equal(t, 100, a.Len())
+
+	var at time.Duration
+	for _, s := range a.Schedule() {
+		assert.True(t, s.Delay >= s.Backoff/2 && s.Delay <= s.Backoff)
+		assert.True(t, s.Backoff <= time.Minute)
+		at += s.Delay
+		assert.Equal(t, at, s.At)
+	}
+}
+
+func Test_RetryPlanner_Saturates(t *testing.T) {
+	for _, policy := range []RetryPolicy{
+		{Attempts: 64, Base: time.Second},
+		{Attempts: 64, Base: time.Second, Jitter: 0.5},
+		{Attempts: 64, Base: time.Second, Max: math.MaxInt64 / 2},
+	} {
+		p, err := NewRetryPlanner(policy, SeedFromString("a"))
+		assert.NoError(t, err)
+		var prev RetryAttempt
+		for _, s := range p.Schedule() {
+			assert.True(t, s.Backoff >= prev.Backoff && s.At >= prev.At, "%+v after %+v", s, prev)
+			assert.True(t, s.Delay >= 0)
+			if policy.Jitter == 0 {
+				assert.True(t, s.Delay >= prev.Delay, "%+v after %+v", s, prev)
+			}
+			prev = s
+		}
+		assert.Equal(t, time.Duration(math.MaxInt64), prev.At)
+	}
+	p, _ := NewRetryPlanner(RetryPolicy{Attempts: 64, Base: time.Second}, SeedFromString("a"))
+	assert.Equal(t, time.Duration(math.MaxInt64), p.Schedule()[63].Backoff)
+}
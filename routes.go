@@ -0,0 +1,165 @@
+package fastrand64
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// RoutingConfig describes the shape of a random routing table
+type RoutingConfig struct {
+	// Hosts is the number of hosts, at least 1
+	Hosts int
+	// SharedBackends is the size of the pool of backends that may serve several hosts
+	SharedBackends int
+	// MinBackends and MaxBackends bound the backends of each host, 1 <= MinBackends <= MaxBackends
+	MinBackends, MaxBackends int
+	// MaxWeight bounds the backend weights, which are drawn uniformly from [1, MaxWeight]
+	MaxWeight int
+	// Overlap is the chance that each backend of a host comes from the shared pool rather than being its own,
+	// in [0, 1]. Above 0 it needs some SharedBackends
+	Overlap float64
+	// EventRate is the mean number of churn events per second, arrivals are Poisson. 0 means 1
+	EventRate float64
+}
+
+// Backend is one weighted backend of a host
+type Backend struct {
+	Name   string
+	Weight int
+}
+
+// RouteEventKind is the kind of change a RouteEvent makes
+type RouteEventKind int
+
+const (
+	// RouteAddBackend adds Backend to Host
+	RouteAddBackend RouteEventKind = iota
+	// RouteRemoveBackend removes Backend from Host
+	RouteRemoveBackend
+	// RouteReweight changes the weight of Backend on Host
+	RouteReweight
+)
+
+// RouteEvent is one change to a routing table
+type RouteEvent struct {
+	At      time.Duration // time of the event since the table was generated
+	Kind    RouteEventKind
+	Host    string
+	Backend Backend
+}
+
+// RoutingTable maps hosts to their weighted backends
+type RoutingTable struct {
+	Hosts  []string // in a stable order
+	Routes map[string][]Backend
+}
+
+// RouteGenerator generates a random routing table and then a stream of churn events against it, as seeded
+// fixtures for proxy and control plane tests. The same seed and config always give the same table and
+// events. Not threadsafe
+type RouteGenerator struct {
+	r       *UnsafeXoshiro256ssRNG
+	cfg     RoutingConfig
+	table   RoutingTable
+	private int // private backends created so far, for naming the next one
+	at      float64
+}
+
+// NewRouteGenerator generates the initial table from seed
+func NewRouteGenerator(seed Seed, cfg RoutingConfig) (*RouteGenerator, error) {
+	if cfg.Hosts < 1 {
+		return nil, invalidArgument("NewRouteGenerator: %d hosts", cfg.Hosts)
+	}
+	if cfg.MinBackends < 1 || cfg.MaxBackends < cfg.MinBackends {
+		return nil, invalidArgument("NewRouteGenerator: need 1 <= MinBackends <= MaxBackends, got %d and %d", cfg.MinBackends, cfg.MaxBackends)
+	}
+	if cfg.MaxWeight < 1 {
+		return nil, invalidArgument("NewRouteGenerator: MaxWeight %d", cfg.MaxWeight)
+	}
+	if !(cfg.Overlap >= 0 && cfg.Overlap <= 1) || cfg.SharedBackends < 0 || (cfg.Overlap > 0 && cfg.SharedBackends == 0) {
+		return nil, invalidArgument("NewRouteGenerator: overlap %v with %d shared backends", cfg.Overlap, cfg.SharedBackends)
+	}
+	if !(cfg.EventRate >= 0) || math.IsInf(cfg.EventRate, 0) {
+		return nil, invalidArgument("NewRouteGenerator: event rate %v", cfg.EventRate)
+	}
+	if cfg.EventRate == 0 {
+		cfg.EventRate = 1
+	}
+	g := &RouteGenerator{
+		r:     NewUnsafeXoshiro256ssRNGFromSeed(seed),
+		cfg:   cfg,
+		table: RoutingTable{Hosts: make([]string, cfg.Hosts), Routes: make(map[string][]Backend, cfg.Hosts)},
+	}
+	for i := range g.table.Hosts {
+		host := "host-" + strconv.Itoa(i)
+		g.table.Hosts[i] = host
+		n := cfg.MinBackends + Intn(g.r, cfg.MaxBackends-cfg.MinBackends+1)
+		for j := 0; j < n; j++ {
+			g.table.Routes[host] = append(g.table.Routes[host], g.newBackend(host))
+		}
+	}
+	return g, nil
+}
+
+// newBackend picks a backend that host doesn't have yet, from the shared pool with probability Overlap
+func (g *RouteGenerator) newBackend(host string) Backend {
+	b := Backend{Weight: 1 + Intn(g.r, g.cfg.MaxWeight)}
+	if Float64(g.r) < g.cfg.Overlap {
+		b.Name = "shared-" + strconv.Itoa(Intn(g.r, g.cfg.SharedBackends))
+		if g.indexOf(host, b.Name) < 0 {
+			return b
+		}
+		// already on this host, so it gets one of its own instead
+	}
+	b.Name = "backend-" + strconv.Itoa(g.private)
+	g.private++
+	return b
+}
+
+func (g *RouteGenerator) indexOf(host, name string) int {
+	for i, b := range g.table.Routes[host] {
+		if b.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Table returns a copy of the routing table as it stands after the events so far
+func (g *RouteGenerator) Table() RoutingTable {
+	t := RoutingTable{Hosts: append([]string(nil), g.table.Hosts...), Routes: make(map[string][]Backend, len(g.table.Routes))}
+	for host, backends := range g.table.Routes {
+		t.Routes[host] = append([]Backend(nil), backends...)
+	}
+	return t
+}
+
+// Next applies the next random churn event to the table and returns it. A host never loses its last backend,
+// and never grows past MaxBackends
+func (g *RouteGenerator) Next() RouteEvent {
+	g.at += ExpFloat64(g.r) / g.cfg.EventRate
+	host := g.table.Hosts[Intn(g.r, len(g.table.Hosts))]
+	backends := g.table.Routes[host]
+	e := RouteEvent{At: time.Duration(g.at * float64(time.Second)), Kind: RouteEventKind(Intn(g.r, 3)), Host: host}
+	if e.Kind == RouteRemoveBackend && len(backends) <= 1 {
+		e.Kind = RouteAddBackend
+	}
+	if e.Kind == RouteAddBackend && len(backends) >= g.cfg.MaxBackends {
+		e.Kind = RouteReweight
+	}
+	switch e.Kind {
+	case RouteAddBackend:
+		e.Backend = g.newBackend(host)
+		g.table.Routes[host] = append(backends, e.Backend)
+	case RouteRemoveBackend:
+		i := Intn(g.r, len(backends))
+		e.Backend = backends[i]
+		g.table.Routes[host] = append(backends[:i:i], backends[i+1:]...)
+	case RouteReweight:
+		i := Intn(g.r, len(backends))
+		backends[i].Weight = 1 + Intn(g.r, g.cfg.MaxWeight)
+		e.Backend = backends[i]
+	}
+	return e
+}
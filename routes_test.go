@@ -0,0 +1,92 @@
+package fastrand64
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewRouteGenerator_Errors(t *testing.T) {
+	seed := SeedFromString(t.Name())
+	good := RoutingConfig{Hosts: 3, SharedBackends: 4, MinBackends: 1, MaxBackends: 3, MaxWeight: 10, Overlap: 0.5}
+	for _, mod := range []func(c *RoutingConfig){
+		func(c *RoutingConfig) { c.Hosts = 0 },
+		func(c *RoutingConfig) { c.MinBackends = 0 },
+		func(c *RoutingConfig) { c.MaxBackends = 0 },
+		func(c *RoutingConfig) { c.MaxWeight = 0 },
+		func(c *RoutingConfig) { c.Overlap = 2 },
+		func(c *RoutingConfig) { c.SharedBackends = 0 },
+		func(c *RoutingConfig) { c.EventRate = -1 },
+	} {
+		cfg := good
+		mod(&cfg)
+		g, err := NewRouteGenerator(seed, cfg)
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+		assert.Nil(t, g)
+	}
+}
+
+func checkRoutingTable(t *testing.T, table RoutingTable, cfg RoutingConfig) {
+	assert.Equal(t, cfg.Hosts, len(table.Hosts))
+	for _, host := range table.Hosts {
+		backends := table.Routes[host]
+		assert.True(t, len(backends) >= 1 && len(backends) <= cfg.MaxBackends)
+		names := map[string]bool{}
+		for _, b := range backends {
+			assert.False(t, names[b.Name], "%s has %s twice", host, b.Name)
+			names[b.Name] = true
+			assert.True(t, b.Weight >= 1 && b.Weight <= cfg.MaxWeight)
+		}
+	}
+}
+
+func Test_RouteGenerator(t *testing.T) {
+	cfg := RoutingConfig{Hosts: 50, SharedBackends: 10, MinBackends: 2, MaxBackends: 5, MaxWeight: 100, Overlap: 0.5, EventRate: 10}
+	g, err := NewRouteGenerator(SeedFromString(t.Name()), cfg)
+	assert.NoError(t, err)
+	again, _ := NewRouteGenerator(SeedFromString(t.Name()), cfg)
+	assert.Equal(t, g.Table(), again.Table())
+
+	table := g.Table()
+	checkRoutingTable(t, table, cfg)
+	shared, total := 0, 0
+	for _, backends := range table.Routes {
+		for _, b := range backends {
+			if strings.HasPrefix(b.Name, "shared-") {
+				shared++
+			}
+			total++
+		}
+	}
+	assert.InDelta(t, 0.5, float64(shared)/float64(total), 0.15)
+
+	var last time.Duration
+	kinds := make([]int, 3)
+	for i := 0; i < 1000; i++ {
+		e := g.Next()
+		assert.Equal(t, e, again.Next())
+		assert.True(t, e.At >= last)
+		last = e.At
+		kinds[e.Kind]++
+	}
+	for _, k := range kinds {
+		assert.Greater(t, k, 200)
+	}
+	assert.InDelta(t, float64(100*time.Second), float64(last), float64(15*time.Second))
+	checkRoutingTable(t, g.Table(), cfg)
+}
+
+func Test_RouteGenerator_TableIsACopy(t *testing.T) {
+	g, _ := NewRouteGenerator(SeedFromString(t.Name()), RoutingConfig{Hosts: 1, MinBackends: 1, MaxBackends: 1, MaxWeight: 1})
+	table := g.Table()
+	table.Routes["host-0"][0].Weight = 99
+	assert.Equal(t, 1, g.Table().Routes["host-0"][0].Weight)
+
+	// with a single backend allowed every event is a reweight
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, RouteReweight, g.Next().Kind)
+	}
+}
@@ -0,0 +1,55 @@
+package fastrand64
+
+// sampleDenseRatio is where SampleInts stops tracking swaps in a map and just permutes a dense slice,
+// once k is this large a fraction of n the slice is smaller and faster than the map
+const sampleDenseRatio = 4
+
+// SampleInts returns k distinct ints chosen uniformly from [0..n), in random order, from a thread unsafe RNG.
+//
+// It runs a partial Fisher-Yates shuffle over a virtual [0..n) array, recording only the swapped positions,
+// so time and memory are O(k) even when n is huge. It panics if k < 0, n < 0 or k > n
+func SampleInts(r UnsafeRNG, n, k int) []int {
+	if n < 0 || k < 0 || k > n {
+		panic("invalid argument to SampleInts")
+	}
+	result := make([]int, k)
+	if k*sampleDenseRatio >= n {
+		a := make([]int, n)
+		for i := range a {
+			a[i] = i
+		}
+		for i := 0; i < k; i++ {
+			j := i + int(Uint64n(r, uint64(n-i)))
+			a[i], a[j] = a[j], a[i]
+		}
+		copy(result, a[:k])
+		return result
+	}
+
+	// swapped[i] is the value at position i of the virtual array, if it isn't i itself
+	swapped := make(map[int]int, k)
+	for i := 0; i < k; i++ {
+		j := i + int(Uint64n(r, uint64(n-i)))
+		vj, ok := swapped[j]
+		if !ok {
+			vj = j
+		}
+		vi, ok := swapped[i]
+		if !ok {
+			vi = i
+		}
+		result[i] = vj
+		swapped[j] = vi
+	}
+	return result
+}
+
+// SampleInts returns k distinct ints chosen uniformly from [0..n), in random order. Threadsafe
+//
+// Time and memory are O(k) even when n is huge. It panics if k < 0, n < 0 or k > n
+func (s *ThreadsafePoolRNG) SampleInts(n, k int) []int {
+	r, pool := s.get()
+	x := SampleInts(r, n, k)
+	pool.put(r)
+	return x
+}
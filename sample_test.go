@@ -0,0 +1,50 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SampleInts(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	for _, c := range []struct{ n, k int }{{0, 0}, {10, 0}, {10, 10}, {10, 3}, {1000, 5}, {math.MaxInt32, 100}} {
+		s := SampleInts(rng, c.n, c.k)
+		assert.Equal(t, c.k, len(s))
+		seen := map[int]bool{}
+		for _, v := range s {
+			assert.True(t, v >= 0 && v < c.n)
+			assert.False(t, seen[v])
+			seen[v] = true
+		}
+	}
+	assert.Panics(t, func() { SampleInts(rng, 3, 4) })
+	assert.Panics(t, func() { SampleInts(rng, -1, 0) })
+}
+
+func Test_SampleInts_Uniform(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	// exercise both the sparse and the dense paths
+	for _, k := range []int{2, 10} {
+		counts := make([]int, 40)
+		first := make([]int, 40)
+		for i := 0; i < 20000; i++ {
+			s := SampleInts(rng, 40, k)
+			first[s[0]]++
+			for _, v := range s {
+				counts[v]++
+			}
+		}
+		for i := range counts {
+			assert.InDelta(t, 20000*k/40, counts[i], 6*math.Sqrt(float64(20000*k/40)), "k=%d", k)
+			assert.InDelta(t, 500, first[i], 6*math.Sqrt(500), "k=%d", k)
+		}
+	}
+}
+
+func Test_SafeRNG_SampleInts(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	s := rng.SampleInts(math.MaxInt, 3)
+	assert.Equal(t, 3, len(s))
+}
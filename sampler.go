@@ -0,0 +1,55 @@
+package fastrand64
+
+import "sync/atomic"
+
+// Sampler keeps a fixed fraction of calls, for trace and log sampling on hot paths. Sample draws one Uint64 and
+// compares its top 53 bits against a precomputed threshold, there's no float math or locking per call, and the
+// rate can be changed while it is in use. Threadsafe
+type Sampler struct {
+	rng       *ThreadsafePoolRNG
+	threshold atomic.Uint64 // draws with their top 53 bits below this are sampled
+}
+
+// NewSampler creates a Sampler drawing from rng that keeps about rate of the calls, rate must be in [0, 1]
+func NewSampler(rng *ThreadsafePoolRNG, rate float64) (*Sampler, error) {
+	if rng == nil {
+		return nil, invalidArgument("NewSampler: nil source")
+	}
+	s := &Sampler{rng: rng}
+	if err := s.SetRate(rate); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// rateThreshold converts a rate to the threshold for 53 random bits
+func rateThreshold(fn string, rate float64) (uint64, error) {
+	if !(rate >= 0 && rate <= 1) {
+		return 0, invalidArgument("%s: rate %v not in [0, 1]", fn, rate)
+	}
+	return uint64(rate * (1 << 53)), nil
+}
+
+// SetRate changes the rate, calls already under way may still use the old one
+func (s *Sampler) SetRate(rate float64) error {
+	t, err := rateThreshold("SetRate", rate)
+	if err != nil {
+		return err
+	}
+	s.threshold.Store(t)
+	return nil
+}
+
+// Rate returns the rate, rounded to the 2^-53 steps the threshold is kept in
+func (s *Sampler) Rate() float64 {
+	return float64(s.threshold.Load()) / (1 << 53)
+}
+
+// Sample returns true for about rate of its calls
+func (s *Sampler) Sample() bool {
+	t := s.threshold.Load()
+	if t == 0 {
+		return false
+	}
+	return s.rng.Uint64()>>11 < t
+}
@@ -0,0 +1,76 @@
+package fastrand64
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewSampler_Errors(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for _, rate := range []float64{-0.1, 1.1, math.NaN()} {
+		s, err := NewSampler(rng, rate)
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+		assert.Nil(t, s)
+	}
+	_, err := NewSampler(nil, 0.5)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+func Test_Sampler_Rate(t *testing.T) {
+	s, err := NewSampler(NewSyncPoolXoshiro256ssRNG(), 0.01)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.01, s.Rate(), 1e-15)
+	n := 0
+	for i := 0; i < 100000; i++ {
+		if s.Sample() {
+			n++
+		}
+	}
+	assert.InDelta(t, 1000, n, 200)
+
+	for _, rate := range []float64{0, 1} {
+		assert.NoError(t, s.SetRate(rate))
+		assert.Equal(t, rate, s.Rate())
+		for i := 0; i < 1000; i++ {
+			assert.Equal(t, rate == 1, s.Sample())
+		}
+	}
+	assert.True(t, errors.Is(s.SetRate(2), ErrInvalidArgument))
+	assert.Equal(t, 1.0, s.Rate())
+}
+
+func Test_Sampler_Concurrent(t *testing.T) {
+	s, _ := NewSampler(NewSyncPoolXoshiro256ssRNG(), 0.5)
+	var wg sync.WaitGroup
+	counts := make([]int, 8)
+	for g := range counts {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 10000; i++ {
+				if s.Sample() {
+					counts[g]++
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	for _, n := range counts {
+		assert.InDelta(t, 5000, n, 300)
+	}
+}
+
+func Benchmark_Sampler_Sample_Parallel(b *testing.B) {
+	s, _ := NewSampler(NewSyncPoolXoshiro256ssRNG(), 0.01)
+	b.RunParallel(func(pb *testing.PB) {
+		r := s.Sample()
+		for pb.Next() {
+			r = s.Sample()
+		}
+		BenchSink = &r
+	})
+}
@@ -0,0 +1,92 @@
+package fastrand64
+
+import (
+	"crypto/hmac"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"sync/atomic"
+)
+
+// Seed is 256 bits of seed material. Unlike a bare int64 it is big enough to key any generator in this package,
+// and independent child seeds can be derived from it by label, so one configured seed can safely feed many
+// generators without them overlapping
+type Seed [32]byte
+
+// SeedFromCrypto returns a seed read from crypto/rand
+func SeedFromCrypto() (Seed, error) {
+	var s Seed
+	_, err := cryptorand.Read(s[:])
+	return s, err
+}
+
+// SeedFromString hashes any string, like a test name or a config value, into a seed
+func SeedFromString(str string) Seed {
+	return Seed(sha256.Sum256([]byte(str)))
+}
+
+// SeedFromInt64 expands a legacy int64 seed with splitmix64, the same way the int64 constructors do
+func SeedFromInt64(seed int64) Seed {
+	var s Seed
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(s[i*8:], Splitmix64(uint64(seed)+uint64(i)))
+	}
+	return s
+}
+
+// DeriveSeed returns an independent child seed for label, HMAC-SHA256 keyed by the parent seed
+func (s Seed) DeriveSeed(label string) Seed {
+	mac := hmac.New(sha256.New, s[:])
+	mac.Write([]byte(label))
+	var child Seed
+	copy(child[:], mac.Sum(nil))
+	return child
+}
+
+// DeriveUint64 returns an independent uint64 for label, for feeding APIs that still take a number
+func (s Seed) DeriveUint64(label string) uint64 {
+	child := s.DeriveSeed(label)
+	return binary.LittleEndian.Uint64(child[:])
+}
+
+// String returns the seed as hex, so it can be logged and fed back in with ParseSeed
+func (s Seed) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// ParseSeed parses the hex form returned by Seed.String
+func ParseSeed(str string) (Seed, error) {
+	var s Seed
+	b, err := hex.DecodeString(str)
+	if err != nil || len(b) != len(s) {
+		return s, invalidArgument("ParseSeed: want %d hex encoded bytes", len(s))
+	}
+	copy(s[:], b)
+	return s, nil
+}
+
+// NewUnsafeXoshiro256ssRNGFromSeed creates a new Thread unsafe PRNG generator using the seed directly as its
+// 256 bit state, the all zero seed (the only invalid state) is replaced by its splitmix64 expansion
+func NewUnsafeXoshiro256ssRNGFromSeed(seed Seed) *UnsafeXoshiro256ssRNG {
+	var state [4]uint64
+	for i := range state {
+		state[i] = binary.LittleEndian.Uint64(seed[i*8:])
+	}
+	r, err := NewUnsafeXoshiro256ssRNGFromState(state)
+	if err != nil {
+		return NewUnsafeXoshiro256ssRNG(0)
+	}
+	return r
+}
+
+// NewSyncPoolXoshiro256ssRNGFromSeed creates a thread safe pooled xoshiro256** generator whose n-th generator is
+// seeded with seed.DeriveSeed("pool/<n>"), so the pool is reproducible for single goroutine use
+func NewSyncPoolXoshiro256ssRNGFromSeed(seed Seed) *ThreadsafePoolRNG {
+	var n uint64
+	return NewSyncPoolRNG(func() UnsafeRNG {
+		i := atomic.AddUint64(&n, 1) - 1
+		return NewUnsafeXoshiro256ssRNGFromSeed(seed.DeriveSeed("pool/" + strconv.FormatUint(i, 10)))
+	})
+}
@@ -0,0 +1,58 @@
+package fastrand64
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Seed_Derive(t *testing.T) {
+	s := SeedFromString("Test_Seed_Derive")
+	assert.Equal(t, s, SeedFromString("Test_Seed_Derive"))
+	assert.NotEqual(t, s, SeedFromString("something else"))
+
+	a := s.DeriveSeed("a")
+	assert.Equal(t, a, s.DeriveSeed("a"))
+	assert.NotEqual(t, a, s.DeriveSeed("b"))
+	assert.NotEqual(t, a, SeedFromString("other").DeriveSeed("a"))
+	assert.NotEqual(t, s.DeriveUint64("a"), s.DeriveUint64("b"))
+}
+
+func Test_Seed_Crypto(t *testing.T) {
+	a, err := SeedFromCrypto()
+	assert.NoError(t, err)
+	b, err := SeedFromCrypto()
+	assert.NoError(t, err)
+	assert.NotEqual(t, a, b)
+}
+
+func Test_Seed_StringParse(t *testing.T) {
+	s := SeedFromInt64(42)
+	parsed, err := ParseSeed(s.String())
+	assert.NoError(t, err)
+	assert.Equal(t, s, parsed)
+
+	_, err = ParseSeed("abcd")
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	_, err = ParseSeed("zz")
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+func Test_NewUnsafeXoshiro256ssRNGFromSeed(t *testing.T) {
+	s := SeedFromString("x")
+	assert.Equal(t, NewUnsafeXoshiro256ssRNGFromSeed(s).Uint64(), NewUnsafeXoshiro256ssRNGFromSeed(s).Uint64())
+	// the zero seed must not produce a stuck generator
+	r := NewUnsafeXoshiro256ssRNGFromSeed(Seed{})
+	assert.NotEqual(t, uint64(0), r.Uint64()|r.Uint64())
+}
+
+func Test_NewSyncPoolXoshiro256ssRNGFromSeed(t *testing.T) {
+	s := SeedFromString("pool")
+	rng := NewSyncPoolXoshiro256ssRNGFromSeed(s)
+	assert.Equal(t, NewUnsafeXoshiro256ssRNGFromSeed(s.DeriveSeed("pool/0")).Uint64(), rng.Uint64())
+}
+
+func Test_NewUnsafeChaCha8RNGFromSeed(t *testing.T) {
+	assert.Equal(t, NewUnsafeChaCha8RNG(7).Uint64(), NewUnsafeChaCha8RNGFromSeed(SeedFromInt64(7)).Uint64())
+}
@@ -0,0 +1,69 @@
+package fastrand64
+
+import "errors"
+
+// ErrSequenceExhausted is what a non cycling SequenceRNG panics with once it runs out of values
+var ErrSequenceExhausted = errors.New("fastrand64: SequenceRNG ran out of values")
+
+// FixedRNG is an UnsafeRNG that always returns the same value, for tests of code taking an UnsafeRNG.
+// FixedRNG(0) drives Float64 to 0 and Uint64n to 0, FixedRNG(math.MaxUint64) drives them to their maximums
+type FixedRNG uint64
+
+// Uint64 returns the fixed value
+func (r FixedRNG) Uint64() uint64 {
+	return uint64(r)
+}
+
+// SequenceRNG is an UnsafeRNG that replays a scripted list of values, for tests of code taking an UnsafeRNG.
+// It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+//
+// Remember that helpers like Uint64n and Float64Range may draw more than one value, rejection sampling draws
+// again until a value fits
+type SequenceRNG struct {
+	values []uint64
+	pos    int
+	cycle  bool
+}
+
+// NewSequenceRNG creates a SequenceRNG that replays values and then starts over from the first one.
+// It panics if values is empty
+func NewSequenceRNG(values ...uint64) *SequenceRNG {
+	if len(values) == 0 {
+		panic("invalid argument to NewSequenceRNG")
+	}
+	return &SequenceRNG{values: values, cycle: true}
+}
+
+// NewStrictSequenceRNG creates a SequenceRNG that replays values once, then panics with ErrSequenceExhausted,
+// so a test fails loudly when the code under test draws more values than expected
+func NewStrictSequenceRNG(values ...uint64) *SequenceRNG {
+	return &SequenceRNG{values: values}
+}
+
+// Uint64 returns the next scripted value
+func (r *SequenceRNG) Uint64() uint64 {
+	if r.pos == len(r.values) {
+		if !r.cycle {
+			panic(ErrSequenceExhausted)
+		}
+		r.pos = 0
+	}
+	x := r.values[r.pos]
+	r.pos++
+	return x
+}
+
+// Drawn returns how many values have been returned since the last wrap around or Reset
+func (r *SequenceRNG) Drawn() int {
+	return r.pos
+}
+
+// Remaining returns how many values are left before the sequence wraps around or runs out
+func (r *SequenceRNG) Remaining() int {
+	return len(r.values) - r.pos
+}
+
+// Reset starts the sequence over from the first value
+func (r *SequenceRNG) Reset() {
+	r.pos = 0
+}
@@ -0,0 +1,42 @@
+package fastrand64
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FixedRNG(t *testing.T) {
+	assert.Equal(t, uint64(7), FixedRNG(7).Uint64())
+	assert.Equal(t, 0.0, Float64(FixedRNG(0)))
+	assert.Equal(t, uint64(9), Uint64n(FixedRNG(math.MaxUint64), 10))
+}
+
+func Test_SequenceRNG(t *testing.T) {
+	r := NewSequenceRNG(1, 2, 3)
+	assert.Equal(t, 3, r.Remaining())
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, []uint64{1, 2, 3}, []uint64{r.Uint64(), r.Uint64(), r.Uint64()})
+		assert.Equal(t, 3, r.Drawn())
+		assert.Equal(t, 0, r.Remaining())
+	}
+	r.Uint64()
+	assert.Equal(t, 1, r.Drawn())
+	r.Reset()
+	assert.Equal(t, uint64(1), r.Uint64())
+	assert.Panics(t, func() { NewSequenceRNG() })
+}
+
+func Test_StrictSequenceRNG(t *testing.T) {
+	r := NewStrictSequenceRNG(5, 6)
+	assert.Equal(t, uint64(5), r.Uint64())
+	assert.Equal(t, uint64(6), r.Uint64())
+	assert.Equal(t, 0, r.Remaining())
+	assert.PanicsWithValue(t, ErrSequenceExhausted, func() { r.Uint64() })
+	r.Reset()
+	assert.Equal(t, uint64(5), r.Uint64())
+
+	// an empty strict sequence is fine until something draws from it
+	assert.Panics(t, func() { NewStrictSequenceRNG().Uint64() })
+}
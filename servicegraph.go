@@ -0,0 +1,119 @@
+package fastrand64
+
+import "strconv"
+
+// ServiceGraphConfig describes the shape of a random ServiceGraph
+type ServiceGraphConfig struct {
+	// Services is the number of services, at least 1
+	Services int
+	// MinFanOut and MaxFanOut bound how many services each one depends on. Services near the bottom of the
+	// graph, or whose candidates are all full, get as many as are left
+	MinFanOut, MaxFanOut int
+	// MaxFanIn caps how many services may depend on any one service, 0 means no cap
+	MaxFanIn int
+	// MinFailure and MaxFailure bound the failure probability of each service, drawn uniformly between them
+	MinFailure, MaxFailure float64
+}
+
+// ServiceNode is one service of a ServiceGraph
+type ServiceNode struct {
+	Name        string
+	FailureProb float64
+}
+
+// ServiceEdge says From calls To, Weight is To's share of From's outgoing calls, so the weights of
+// a service's dependencies sum to 1
+type ServiceEdge struct {
+	From, To int
+	Weight   float64
+}
+
+// ServiceGraph is a random directed acyclic graph of service dependencies, annotated with call weights and
+// failure probabilities, to drive chaos experiment planning reproducibly.
+//
+// Edges always point from a lower index to a higher one, so index order is a topological order
+type ServiceGraph struct {
+	Services []ServiceNode
+	Edges    []ServiceEdge // grouped by From, in index order
+	deps     [][]int       // deps[i] are the indexes into Edges of service i's dependencies
+}
+
+// NewServiceGraph generates a ServiceGraph from seed, the same seed and config always give the same graph
+func NewServiceGraph(seed Seed, cfg ServiceGraphConfig) (*ServiceGraph, error) {
+	if cfg.Services < 1 {
+		return nil, invalidArgument("NewServiceGraph: %d services", cfg.Services)
+	}
+	if cfg.MinFanOut < 0 || cfg.MaxFanOut < cfg.MinFanOut {
+		return nil, invalidArgument("NewServiceGraph: need 0 <= MinFanOut <= MaxFanOut, got %d and %d", cfg.MinFanOut, cfg.MaxFanOut)
+	}
+	if cfg.MaxFanIn < 0 {
+		return nil, invalidArgument("NewServiceGraph: MaxFanIn %d", cfg.MaxFanIn)
+	}
+	if !(cfg.MinFailure >= 0 && cfg.MinFailure <= cfg.MaxFailure && cfg.MaxFailure <= 1) {
+		return nil, invalidArgument("NewServiceGraph: need 0 <= MinFailure <= MaxFailure <= 1, got %v and %v", cfg.MinFailure, cfg.MaxFailure)
+	}
+	r := NewUnsafeXoshiro256ssRNGFromSeed(seed)
+	g := &ServiceGraph{Services: make([]ServiceNode, cfg.Services), deps: make([][]int, cfg.Services)}
+	for i := range g.Services {
+		g.Services[i] = ServiceNode{
+			Name:        "svc-" + strconv.Itoa(i),
+			FailureProb: cfg.MinFailure + (cfg.MaxFailure-cfg.MinFailure)*Float64(r),
+		}
+	}
+	fanIn := make([]int, cfg.Services)
+	candidates := make([]int, 0, cfg.Services)
+	weights := make([]float64, 0, cfg.MaxFanOut)
+	for i := 0; i < cfg.Services; i++ {
+		candidates = candidates[:0]
+		for j := i + 1; j < cfg.Services; j++ {
+			if cfg.MaxFanIn == 0 || fanIn[j] < cfg.MaxFanIn {
+				candidates = append(candidates, j)
+			}
+		}
+		fanOut := cfg.MinFanOut + Intn(r, cfg.MaxFanOut-cfg.MinFanOut+1)
+		if fanOut > len(candidates) {
+			fanOut = len(candidates)
+		}
+		if fanOut == 0 {
+			continue
+		}
+		weights = weights[:0]
+		total := 0.0
+		for k := 0; k < fanOut; k++ {
+			w := Float64OpenClosed(r)
+			weights = append(weights, w)
+			total += w
+		}
+		for k, c := range SampleInts(r, len(candidates), fanOut) {
+			to := candidates[c]
+			fanIn[to]++
+			g.deps[i] = append(g.deps[i], len(g.Edges))
+			g.Edges = append(g.Edges, ServiceEdge{From: i, To: to, Weight: weights[k] / total})
+		}
+	}
+	return g, nil
+}
+
+// Dependencies returns the edges from service i to the services it calls
+func (g *ServiceGraph) Dependencies(i int) []ServiceEdge {
+	edges := make([]ServiceEdge, len(g.deps[i]))
+	for k, e := range g.deps[i] {
+		edges[k] = g.Edges[e]
+	}
+	return edges
+}
+
+// Roots returns the services nothing depends on, the entry points of the graph
+func (g *ServiceGraph) Roots() []int {
+	called := make([]bool, len(g.Services))
+	for _, e := range g.Edges {
+		called[e.To] = true
+	}
+	var roots []int
+	for i, c := range called {
+		if !c {
+			roots = append(roots, i)
+		}
+	}
+	return roots
+}
@@ -0,0 +1,60 @@
+package fastrand64
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewServiceGraph_Errors(t *testing.T) {
+	seed := SeedFromString(t.Name())
+	for _, cfg := range []ServiceGraphConfig{
+		{Services: 0},
+		{Services: 5, MinFanOut: 3, MaxFanOut: 2},
+		{Services: 5, MaxFanIn: -1},
+		{Services: 5, MinFailure: 0.5, MaxFailure: 0.1},
+		{Services: 5, MaxFailure: 2},
+	} {
+		g, err := NewServiceGraph(seed, cfg)
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+		assert.Nil(t, g)
+	}
+}
+
+func Test_ServiceGraph_Shape(t *testing.T) {
+	cfg := ServiceGraphConfig{Services: 200, MinFanOut: 1, MaxFanOut: 4, MaxFanIn: 3, MinFailure: 0.01, MaxFailure: 0.05}
+	g, err := NewServiceGraph(SeedFromString(t.Name()), cfg)
+	assert.NoError(t, err)
+	again, _ := NewServiceGraph(SeedFromString(t.Name()), cfg)
+	assert.Equal(t, g, again)
+
+	fanIn := make([]int, cfg.Services)
+	for i, s := range g.Services {
+		assert.GreaterOrEqual(t, s.FailureProb, 0.01)
+		assert.LessOrEqual(t, s.FailureProb, 0.05)
+
+		deps := g.Dependencies(i)
+		assert.LessOrEqual(t, len(deps), 4)
+		total := 0.0
+		seen := map[int]bool{}
+		for _, e := range deps {
+			assert.Equal(t, i, e.From)
+			assert.Greater(t, e.To, i)
+			assert.False(t, seen[e.To])
+			seen[e.To] = true
+			fanIn[e.To]++
+			total += e.Weight
+		}
+		if len(deps) > 0 {
+			assert.InDelta(t, 1, total, 1e-9)
+		}
+	}
+	for _, n := range fanIn {
+		assert.LessOrEqual(t, n, 3)
+	}
+	assert.Contains(t, g.Roots(), 0)
+	for _, root := range g.Roots() {
+		assert.Equal(t, 0, fanIn[root])
+	}
+}
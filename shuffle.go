@@ -0,0 +1,133 @@
+package fastrand64
+
+import (
+	"math"
+	"sort"
+)
+
+// Shuffle pseudo-randomizes the order of the elements of s with an unbiased Fisher-Yates shuffle.
+//
+// r can be any UnsafeRNG. A ThreadsafePoolRNG is safe to share between goroutines, and only has a generator
+// checked out once for the whole shuffle
+func Shuffle[T any](r UnsafeRNG, s []T) {
+	if p, ok := r.(*ThreadsafePoolRNG); ok {
+		g, pool := p.get()
+		shuffleSlice(g, s)
+		pool.put(g)
+		return
+	}
+	shuffleSlice(r, s)
+}
+
+func shuffleSlice[T any](r UnsafeRNG, s []T) {
+	for i := len(s) - 1; i > 0; i-- {
+		j := Uint64n(r, uint64(i)+1)
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// ShuffleFirstK moves k elements of s chosen uniformly without replacement to its front, in random order,
+// with a partial Fisher-Yates shuffle that stops after k steps. It is O(k) however long s is, so picking a few
+// random candidates from a million doesn't shuffle the million. The rest of s is left in an unspecified order.
+// It panics if k < 0 or k > len(s).
+//
+// Like Shuffle r can be any UnsafeRNG, a ThreadsafePoolRNG only has a generator checked out once
+func ShuffleFirstK[T any](r UnsafeRNG, s []T, k int) {
+	if k < 0 || k > len(s) {
+		panic("invalid argument to ShuffleFirstK")
+	}
+	if p, ok := r.(*ThreadsafePoolRNG); ok {
+		g, pool := p.get()
+		shuffleFirstK(g, s, k)
+		pool.put(g)
+		return
+	}
+	shuffleFirstK(r, s, k)
+}
+
+func shuffleFirstK[T any](r UnsafeRNG, s []T, k int) {
+	for i := 0; i < k; i++ {
+		j := i + int(Uint64n(r, uint64(len(s)-i)))
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// ShuffleFunc pseudo-randomizes the order of n elements from a thread unsafe RNG, like math/rand.Shuffle,
+// swap swaps the elements with indexes i and j. It panics if n < 0
+func ShuffleFunc(r UnsafeRNG, n int, swap func(i, j int)) {
+	if n < 0 {
+		panic("invalid argument to ShuffleFunc")
+	}
+	for i := n - 1; i > 0; i-- {
+		j := int(Uint64n(r, uint64(i)+1))
+		swap(i, j)
+	}
+}
+
+// Shuffle pseudo-randomizes the order of n elements, like math/rand.Shuffle. Threadsafe
+//
+// swap swaps the elements with indexes i and j. It panics if n < 0
+func (s *ThreadsafePoolRNG) Shuffle(n int, swap func(i, j int)) {
+	r, pool := s.get()
+	ShuffleFunc(r, n, swap)
+	pool.put(r)
+}
+
+// WeightedShuffle reorders items so that higher weighted items tend to come first: the order is the same as
+// repeatedly drawing without replacement with probability proportional to weight, so item i comes first with
+// probability weights[i]/sum(weights). It uses the exponential keys method of Efraimidis & Spirakis, each item's
+// sort key is log(u)/weight, so it is O(n log n). Items with zero weight come last, in uniformly random order.
+//
+// items is reordered in place and weights is left alone. Like Shuffle r can be any UnsafeRNG, a ThreadsafePoolRNG
+// only has a generator checked out once. An error is returned if the lengths differ or a weight is negative,
+// NaN or infinite
+func WeightedShuffle[T any](r UnsafeRNG, items []T, weights []float64) error {
+	if len(items) != len(weights) {
+		return invalidArgument("WeightedShuffle: %d items but %d weights", len(items), len(weights))
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	if _, err := checkWeights("WeightedShuffle", weights); err != nil {
+		return err
+	}
+	keys := make([]weightedShuffleKey, len(items))
+	if p, ok := r.(*ThreadsafePoolRNG); ok {
+		g, pool := p.get()
+		fillWeightedShuffleKeys(g, keys, weights)
+		pool.put(g)
+	} else {
+		fillWeightedShuffleKeys(r, keys, weights)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].key != keys[j].key {
+			return keys[i].key > keys[j].key
+		}
+		return keys[i].tie < keys[j].tie
+	})
+	sorted := make([]T, len(items))
+	for i, k := range keys {
+		sorted[i] = items[k.index]
+	}
+	copy(items, sorted)
+	return nil
+}
+
+type weightedShuffleKey struct {
+	key   float64
+	tie   uint64 // orders the zero weight items, whose keys are all -Inf
+	index int
+}
+
+func fillWeightedShuffleKeys(r UnsafeRNG, keys []weightedShuffleKey, weights []float64) {
+	for i, w := range weights {
+		keys[i].index = i
+		if w == 0 {
+			keys[i].key = math.Inf(-1)
+			keys[i].tie = r.Uint64()
+			continue
+		}
+		// log(u)/w orders the same as u^(1/w) but doesn't underflow for small weights
+		keys[i].key = math.Log(Float64OpenClosed(r)) / w
+	}
+}
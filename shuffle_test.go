@@ -0,0 +1,114 @@
+package fastrand64
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Shuffle(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	s := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	Shuffle(rng, s)
+	sort.Strings(s)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "f", "g", "h"}, s)
+
+	Shuffle(rng, []int{})
+	Shuffle(NewUnsafeXoshiro256ssRNG(1), []int{1})
+}
+
+func Test_Shuffle_Uniform(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	counts := map[[3]int]int{}
+	for i := 0; i < 60000; i++ {
+		s := []int{0, 1, 2}
+		Shuffle(rng, s)
+		counts[[3]int{s[0], s[1], s[2]}]++
+	}
+	assert.Equal(t, 6, len(counts))
+	for _, c := range counts {
+		assert.InDelta(t, 10000, c, 500)
+	}
+}
+
+func Test_ShuffleFirstK(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	// every ordered pair of distinct elements is equally likely to end up in front
+	counts := map[[2]int]int{}
+	for i := 0; i < 60000; i++ {
+		s := []int{0, 1, 2, 3}
+		ShuffleFirstK(rng, s, 2)
+		counts[[2]int{s[0], s[1]}]++
+		sort.Ints(s)
+		assert.Equal(t, []int{0, 1, 2, 3}, s)
+	}
+	assert.Equal(t, 12, len(counts))
+	for _, c := range counts {
+		assert.InDelta(t, 5000, c, 400)
+	}
+
+	big := make([]int, 1000000)
+	for i := range big {
+		big[i] = i
+	}
+	ShuffleFirstK(NewSyncPoolXoshiro256ssRNG(), big, 10)
+	assert.NotEqual(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, big[:10])
+	assert.Panics(t, func() { ShuffleFirstK(rng, big, -1) })
+	assert.Panics(t, func() { ShuffleFirstK(rng, []int{1}, 2) })
+	ShuffleFirstK(rng, []int{}, 0)
+}
+
+func Test_SafeRNG_Shuffle(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	s := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	rng.Shuffle(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+	sort.Ints(s)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, s)
+	assert.Panics(t, func() { rng.Shuffle(-1, func(i, j int) {}) })
+}
+
+func Test_WeightedShuffle(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	weights := []float64{1, 2, 3, 4, 0}
+	first := make([]int, 5)
+	secondAfter3 := 0
+	for i := 0; i < 100000; i++ {
+		s := []int{0, 1, 2, 3, 4}
+		assert.NoError(t, WeightedShuffle(rng, s, weights))
+		first[s[0]]++
+		assert.Equal(t, 4, s[4]) // zero weight is always last
+		if s[0] == 3 && s[1] == 2 {
+			secondAfter3++
+		}
+	}
+	for i, w := range weights {
+		want := 100000 * w / 10
+		assert.InDelta(t, want, first[i], 6*math.Sqrt(want)+1, "item %d", i)
+	}
+	// drawing without replacement: P(3 then 2) = 4/10 * 3/6
+	assert.InDelta(t, 20000, secondAfter3, 6*math.Sqrt(20000))
+	assert.Equal(t, []float64{1, 2, 3, 4, 0}, weights)
+}
+
+func Test_WeightedShuffle_ZeroWeights(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	counts := map[[3]string]int{}
+	for i := 0; i < 6000; i++ {
+		s := []string{"hot", "a", "b", "c"}
+		assert.NoError(t, WeightedShuffle(rng, s, []float64{1, 0, 0, 0}))
+		assert.Equal(t, "hot", s[0])
+		counts[[3]string{s[1], s[2], s[3]}]++
+	}
+	assert.Equal(t, 6, len(counts))
+}
+
+func Test_WeightedShuffle_Errors(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	assert.NoError(t, WeightedShuffle(rng, []int{}, nil))
+	for _, w := range [][]float64{{1}, {1, -1}, {1, math.NaN()}, {0, 0}} {
+		assert.True(t, errors.Is(WeightedShuffle(rng, []int{1, 2}, w), ErrInvalidArgument), "%v", w)
+	}
+}
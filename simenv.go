@@ -0,0 +1,72 @@
+package fastrand64
+
+import "time"
+
+// SimEnv bundles a virtual clock with a seeded xoshiro256** generator for discrete event simulations,
+// so both can be checkpointed together and a restored run replays exactly.
+//
+// It is unsafe to use a SimEnv from concurrent goroutines
+type SimEnv struct {
+	now time.Time
+	rng UnsafeXoshiro256ssRNG
+}
+
+// SimSnapshot is the complete state of a SimEnv, its fields are exported so it can be serialized
+type SimSnapshot struct {
+	Now   time.Time
+	State [4]uint64
+}
+
+// NewSimEnv creates a SimEnv whose clock starts at start and whose generator is seeded with seed
+func NewSimEnv(start time.Time, seed int64) *SimEnv {
+	e := &SimEnv{now: start}
+	e.rng.Seed(seed)
+	return e
+}
+
+// Now returns the current virtual time
+func (e *SimEnv) Now() time.Time {
+	return e.now
+}
+
+// Advance moves the virtual clock forward by d, it panics if d is negative
+func (e *SimEnv) Advance(d time.Duration) {
+	if d < 0 {
+		panic("invalid argument to Advance")
+	}
+	e.now = e.now.Add(d)
+}
+
+// AdvanceTo moves the virtual clock forward to t, it panics if t is before the current virtual time
+func (e *SimEnv) AdvanceTo(t time.Time) {
+	if t.Before(e.now) {
+		panic("invalid argument to AdvanceTo")
+	}
+	e.now = t
+}
+
+// RNG returns the environment's generator, it is part of the snapshot so keep using it rather than a copy
+func (e *SimEnv) RNG() *UnsafeXoshiro256ssRNG {
+	return &e.rng
+}
+
+// Uint64 draws from the environment's generator, so a SimEnv can be passed anywhere an UnsafeRNG is wanted
+func (e *SimEnv) Uint64() uint64 {
+	return e.rng.Uint64()
+}
+
+// Snapshot captures the clock and the generator state together
+func (e *SimEnv) Snapshot() SimSnapshot {
+	return SimSnapshot{Now: e.now, State: e.rng.State()}
+}
+
+// Restore rewinds the clock and the generator to a snapshot. The all zero generator state is rejected
+func (e *SimEnv) Restore(s SimSnapshot) error {
+	r, err := NewUnsafeXoshiro256ssRNGFromState(s.State)
+	if err != nil {
+		return err
+	}
+	e.now = s.Now
+	e.rng.s0, e.rng.s1, e.rng.s2, e.rng.s3 = r.s0, r.s1, r.s2, r.s3
+	return nil
+}
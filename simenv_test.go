@@ -0,0 +1,52 @@
+package fastrand64
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SimEnv_SnapshotRestore(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	env := NewSimEnv(start, 42)
+	env.Advance(time.Second)
+	env.Uint64()
+
+	snap := env.Snapshot()
+	var expected []uint64
+	for i := 0; i < 8; i++ {
+		env.Advance(time.Duration(ExpFloat64(env) * float64(time.Second)))
+		expected = append(expected, env.Uint64())
+	}
+	end := env.Now()
+
+	assert.NoError(t, env.Restore(snap))
+	assert.Equal(t, start.Add(time.Second), env.Now())
+	for i := 0; i < 8; i++ {
+		env.Advance(time.Duration(ExpFloat64(env.RNG()) * float64(time.Second)))
+		assert.Equal(t, expected[i], env.Uint64())
+	}
+	assert.Equal(t, end, env.Now())
+}
+
+func Test_SimEnv_Errors(t *testing.T) {
+	env := NewSimEnv(time.Unix(0, 0), 1)
+	assert.Panics(t, func() { env.Advance(-1) })
+	assert.Panics(t, func() { env.AdvanceTo(time.Unix(-1, 0)) })
+	env.AdvanceTo(time.Unix(10, 0))
+	assert.Equal(t, time.Unix(10, 0), env.Now())
+
+	err := env.Restore(SimSnapshot{})
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	assert.Equal(t, time.Unix(10, 0), env.Now())
+}
+
+func Test_UnsafeXoshiro256ssRNG_State(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(7)
+	rng.Uint64()
+	restored, err := NewUnsafeXoshiro256ssRNGFromState(rng.State())
+	assert.NoError(t, err)
+	assert.Equal(t, rng.Uint64(), restored.Uint64())
+}
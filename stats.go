@@ -0,0 +1,71 @@
+package fastrand64
+
+import (
+	"expvar"
+	"sync/atomic"
+	"time"
+	"weak"
+)
+
+// PoolStats counts what a pool has done since it was made, see WithStats
+type PoolStats struct {
+	// Gets is how many times a generator was checked out of the pool, once per threadsafe call
+	Gets uint64
+	// Bytes is how many bytes Read, Bytes, AppendBytes and WriteRandom produced
+	Bytes uint64
+	// Created is how many generators the pool has made, a steady climb means GC is emptying the sync.Pool
+	Created uint64
+	// InUse is how many generators are checked out right now
+	InUse int64
+	// Reseeds is how many times InvalidateAndReseed ran, whether called directly or by ReseedIfRestored,
+	// WatchForRestore or WithReseedInterval, plus how many times ReseedAll or Seed did
+	Reseeds uint64
+}
+
+type poolStats struct {
+	gets    atomic.Uint64
+	bytes   atomic.Uint64
+	created atomic.Uint64
+	inUse   atomic.Int64
+	reseeds atomic.Uint64
+}
+
+// Stats returns the pool's counters, all zero unless it was made with WithStats, WithExpvar or WithStatsCallback.
+// Each counter is read atomically, but not all of them at the same instant
+func (s *ThreadsafePoolRNG) Stats() PoolStats {
+	if s.stats == nil {
+		return PoolStats{}
+	}
+	return PoolStats{
+		Gets:    s.stats.gets.Load(),
+		Bytes:   s.stats.bytes.Load(),
+		Created: s.stats.created.Load(),
+		InUse:   s.stats.inUse.Load(),
+		Reseeds: s.stats.reseeds.Load(),
+	}
+}
+
+// publishExpvar publishes the pool's stats under name. expvar has no way to unpublish, so the pool is only held
+// weakly and shows up as null once it is garbage
+func (s *ThreadsafePoolRNG) publishExpvar(name string) {
+	w := weak.Make(s)
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		if s := w.Value(); s != nil {
+			return s.Stats()
+		}
+		return nil
+	}))
+}
+
+// reportStatsEvery calls fn with the pool's stats every d for as long as it is reachable
+func (s *ThreadsafePoolRNG) reportStatsEvery(d time.Duration, fn func(PoolStats)) {
+	w := weak.Make(s)
+	var tick func()
+	tick = func() {
+		if s := w.Value(); s != nil {
+			fn(s.Stats())
+			time.AfterFunc(d, tick)
+		}
+	}
+	time.AfterFunc(d, tick)
+}
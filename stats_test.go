@@ -0,0 +1,79 @@
+package fastrand64
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Stats(t *testing.T) {
+	s := NewSyncPoolRNG(nil, WithGenerator("xoshiro256ss"), WithStats(), WithPrewarm(2))
+	assert.Equal(t, PoolStats{Created: 2}, s.Stats())
+
+	s.Uint64()
+	s.Intn(10)
+	s.Bytes(100)
+	s.AppendBytes(nil, 20)
+	s.InvalidateAndReseed()
+	stats := s.Stats()
+	assert.Equal(t, uint64(4), stats.Gets)
+	assert.Equal(t, uint64(120), stats.Bytes)
+	assert.Equal(t, int64(0), stats.InUse)
+	assert.Equal(t, uint64(1), stats.Reseeds)
+	assert.True(t, stats.Created >= 2)
+
+	r, pool := s.get()
+	assert.Equal(t, int64(1), s.Stats().InUse)
+	pool.put(r)
+
+	// without the option everything stays zero
+	assert.Equal(t, PoolStats{}, NewSyncPoolXoshiro256ssRNG().Stats())
+}
+
+func Test_Stats_Expvar(t *testing.T) {
+	s := NewSyncPoolRNG(nil, WithGenerator("wyrand"), WithExpvar("fastrand64_test_pool"))
+	s.Uint64()
+	var stats PoolStats
+	assert.NoError(t, json.Unmarshal([]byte(expvar.Get("fastrand64_test_pool").String()), &stats))
+	assert.Equal(t, uint64(1), stats.Gets)
+
+	_, err := TryNewSyncPoolRNG(nil, WithGenerator("wyrand"), WithExpvar("fastrand64_test_pool"))
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+}
+
+func Test_Stats_Callback(t *testing.T) {
+	got := make(chan PoolStats, 1)
+	s := NewSyncPoolRNG(nil, WithGenerator("wyrand"), WithStatsCallback(time.Millisecond, func(stats PoolStats) {
+		select {
+		case got <- stats:
+		default:
+		}
+	}))
+	s.Uint64()
+	select {
+	case stats := <-got:
+		assert.True(t, stats.Gets <= 1)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "callback never ran")
+	}
+
+	for _, opt := range []PoolOption{WithStatsCallback(0, func(PoolStats) {}), WithStatsCallback(time.Second, nil)} {
+		_, err := TryNewSyncPoolRNG(nil, WithGenerator("wyrand"), opt)
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+	}
+}
+
+func Benchmark_SyncPoolXoshiro256ssRNG_Uint64_Parallel_Stats(b *testing.B) {
+	rng := NewSyncPoolRNG(nil, WithGenerator("xoshiro256ss"), WithStats())
+	b.RunParallel(func(pb *testing.PB) {
+		var x uint64
+		for pb.Next() {
+			x += rng.Uint64()
+		}
+		BenchSink = &x
+	})
+}
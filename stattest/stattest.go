@@ -0,0 +1,151 @@
+// Package stattest implements quick statistical quality checks that run against any fastrand64.UnsafeRNG,
+// to sanity check a custom generator before registering it with fastrand64.Register.
+//
+// They are smoke tests in the spirit of NIST SP 800-22, not a replacement for PractRand or TestU01: a
+// generator that fails one is broken, one that passes them all is merely not obviously broken.
+//
+// Example:
+//
+//	for _, res := range stattest.All(myRNG, 1<<16) {
+//		if !res.Passed(0.001) {
+//			log.Printf("%s failed: p=%v", res.Name, res.PValue)
+//		}
+//	}
+package stattest
+
+import (
+	"math"
+	"math/bits"
+
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+// Result is the outcome of one test
+type Result struct {
+	Name string
+	// Statistic is the test's raw statistic, whose meaning depends on the test
+	Statistic float64
+	// PValue is the chance of a statistic at least this extreme from a truly random source
+	PValue float64
+}
+
+// Passed reports whether the p-value is at least alpha. Even a perfect generator fails a test with
+// probability alpha, so use something small like 0.001, and rerun with a new seed before blaming the generator
+func (r Result) Passed(alpha float64) bool {
+	return r.PValue >= alpha
+}
+
+// All runs every test in this package on n words from r, each test on its own n words
+func All(r fastrand64.UnsafeRNG, n int) []Result {
+	return []Result{
+		Monobit(r, n),
+		Runs(r, n),
+		ChiSquareBytes(r, n),
+		SerialCorrelation(r, n),
+	}
+}
+
+func checkWords(n int) {
+	if n < 2 {
+		panic("invalid argument to stattest: need at least 2 words")
+	}
+}
+
+// Monobit checks that ones and zeros are equally common across the bits of n words, NIST's frequency test.
+// The statistic is the excess of ones over zeros, in standard deviations
+func Monobit(r fastrand64.UnsafeRNG, n int) Result {
+	checkWords(n)
+	ones := 0
+	for i := 0; i < n; i++ {
+		ones += bits.OnesCount64(r.Uint64())
+	}
+	nbits := float64(n) * 64
+	z := (2*float64(ones) - nbits) / math.Sqrt(nbits)
+	return Result{Name: "Monobit", Statistic: z, PValue: math.Erfc(math.Abs(z) / math.Sqrt2)}
+}
+
+// Runs checks the number of runs of identical bits across n words, NIST's runs test, which catches
+// generators that flip between ones and zeros too often or too rarely. The statistic is the run count's
+// distance from its expected value, in standard deviations
+func Runs(r fastrand64.UnsafeRNG, n int) Result {
+	checkWords(n)
+	ones := 0
+	runs := 1
+	var prev uint64
+	for i := 0; i < n; i++ {
+		x := r.Uint64()
+		if i == 0 {
+			// the first bit has no bit before it to differ from
+			prev = x << 63
+		}
+		ones += bits.OnesCount64(x)
+		// bits run from the low bit up, and a new run starts wherever a bit differs from the one before it
+		runs += bits.OnesCount64(x ^ (x<<1 | prev>>63))
+		prev = x
+	}
+	nbits := float64(n) * 64
+	pi := float64(ones) / nbits
+	if math.Abs(pi-0.5) >= 2/math.Sqrt(nbits) {
+		// too biased for the runs count to mean anything, Monobit fails too
+		return Result{Name: "Runs", Statistic: math.Inf(1), PValue: 0}
+	}
+	expected := 2 * nbits * pi * (1 - pi)
+	z := (float64(runs) - expected) / (2 * math.Sqrt(nbits) * pi * (1 - pi))
+	return Result{Name: "Runs", Statistic: z, PValue: math.Erfc(math.Abs(z) / math.Sqrt2)}
+}
+
+// ChiSquareBytes checks that all 256 byte values are equally common across the bytes of n words.
+// The statistic is chi-square with 255 degrees of freedom, its p-value from the Wilson-Hilferty approximation
+func ChiSquareBytes(r fastrand64.UnsafeRNG, n int) Result {
+	checkWords(n)
+	var counts [256]int
+	for i := 0; i < n; i++ {
+		x := r.Uint64()
+		for b := 0; b < 8; b++ {
+			counts[byte(x>>(8*b))]++
+		}
+	}
+	expected := float64(n) * 8 / 256
+	chi := 0.0
+	for _, c := range counts {
+		d := float64(c) - expected
+		chi += d * d / expected
+	}
+	return Result{Name: "ChiSquareBytes", Statistic: chi, PValue: chiSquareUpperTail(chi, 255)}
+}
+
+// chiSquareUpperTail approximates P(X >= chi) for k degrees of freedom, plenty accurate for k in the hundreds
+func chiSquareUpperTail(chi float64, k float64) float64 {
+	v := 2 / (9 * k)
+	z := (math.Cbrt(chi/k) - (1 - v)) / math.Sqrt(v)
+	return math.Erfc(z/math.Sqrt2) / 2
+}
+
+// SerialCorrelation checks that each of n words, as a float64 in [0, 1), is uncorrelated with the next.
+// The statistic is the lag one correlation coefficient, which should be near 0
+func SerialCorrelation(r fastrand64.UnsafeRNG, n int) Result {
+	checkWords(n)
+	first := fastrand64.Float64(r)
+	prev := first
+	var sum, sumSq, sumLag float64
+	for i := 1; i < n; i++ {
+		x := fastrand64.Float64(r)
+		sum += prev
+		sumSq += prev * prev
+		sumLag += prev * x
+		prev = x
+	}
+	// wrap around so every value is both a leader and a follower, as in ent
+	sum += prev
+	sumSq += prev * prev
+	sumLag += prev * first
+
+	fn := float64(n)
+	variance := fn*sumSq - sum*sum
+	if variance == 0 {
+		// a constant stream is perfectly correlated with itself
+		return Result{Name: "SerialCorrelation", Statistic: 1, PValue: 0}
+	}
+	c := (fn*sumLag - sum*sum) / variance
+	return Result{Name: "SerialCorrelation", Statistic: c, PValue: math.Erfc(math.Abs(c) * math.Sqrt(fn) / math.Sqrt2)}
+}
@@ -0,0 +1,65 @@
+package stattest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fastrand64 "github.com/villenny/fastrand64-go"
+)
+
+const words = 1 << 15
+
+func Test_GoodGenerators(t *testing.T) {
+	for _, name := range []string{"xoshiro256ss", "wyrand", "chacha8", "aesctr", "pcg64"} {
+		r, err := fastrand64.New(name, 1)
+		assert.NoError(t, err)
+		for _, res := range All(r, words) {
+			assert.True(t, res.Passed(0.0001), "%s %s: %+v", name, res.Name, res)
+		}
+	}
+}
+
+func Test_PValuesUniform(t *testing.T) {
+	// across many seeds the p-values of a good generator should spread over [0, 1)
+	const seeds = 200
+	for i, name := range []string{"Monobit", "Runs", "ChiSquareBytes", "SerialCorrelation"} {
+		low := 0
+		for seed := int64(0); seed < seeds; seed++ {
+			res := All(fastrand64.NewUnsafeXoshiro256ssRNG(seed), 1<<12)[i]
+			assert.Equal(t, name, res.Name)
+			if res.PValue < 0.1 {
+				low++
+			}
+		}
+		assert.InDelta(t, seeds/10, low, 15, name)
+	}
+}
+
+// stuckBitRNG is a good generator with one bit always set
+type stuckBitRNG struct{ r fastrand64.UnsafeRNG }
+
+func (s stuckBitRNG) Uint64() uint64 { return s.r.Uint64() | 1<<20 }
+
+// alternatingRNG flips every bit, 0101...
+type alternatingRNG struct{}
+
+func (alternatingRNG) Uint64() uint64 { return 0x5555555555555555 }
+
+// counterRNG counts up in small steps
+type counterRNG struct{ n uint64 }
+
+func (c *counterRNG) Uint64() uint64 {
+	c.n += 1 << 40
+	return c.n
+}
+
+func Test_BadGenerators(t *testing.T) {
+	assert.False(t, Monobit(fastrand64.FixedRNG(0), words).Passed(0.001))
+	assert.False(t, Monobit(stuckBitRNG{fastrand64.NewUnsafeXoshiro256ssRNG(1)}, words).Passed(0.001))
+	assert.False(t, Runs(alternatingRNG{}, words).Passed(0.001))
+	assert.False(t, Runs(fastrand64.FixedRNG(0), words).Passed(0.001))
+	assert.False(t, ChiSquareBytes(stuckBitRNG{fastrand64.NewUnsafeXoshiro256ssRNG(1)}, words).Passed(0.001))
+	assert.False(t, SerialCorrelation(&counterRNG{}, words).Passed(0.001))
+	assert.False(t, SerialCorrelation(fastrand64.FixedRNG(7), words).Passed(0.001))
+	assert.Panics(t, func() { Monobit(fastrand64.FixedRNG(0), 1) })
+}
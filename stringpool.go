@@ -0,0 +1,109 @@
+package fastrand64
+
+import "math"
+
+// StringPoolConfig describes the values of a StringPool
+type StringPoolConfig struct {
+	// Cardinality is the number of distinct values in the pool at any time, at least 1
+	Cardinality int
+	// Length is the number of random characters in each value, at least 1
+	Length int
+	// Alphabet the characters are drawn from, nil means AlphabetBase62
+	Alphabet *Alphabet
+	// Prefix is prepended to every value
+	Prefix string
+	// Exponent is the Zipf exponent of popularity, the value of rank i is drawn in proportion to
+	// 1/(i+1)^Exponent. 0 makes every value equally likely
+	Exponent float64
+	// Rotation is the fraction of the values replaced by new ones on each Rotate, in [0, 1]
+	Rotation float64
+}
+
+// StringPool emits strings drawn from a pool of exactly Cardinality distinct values with a chosen popularity,
+// optionally rotating values out over time, for testing group by and cardinality limits in analytics systems.
+// The same seed and config always give the same strings. Not threadsafe
+type StringPool struct {
+	r      *UnsafeXoshiro256ssRNG
+	cfg    StringPoolConfig
+	ranks  *aliasTable
+	values []string // by rank, most popular first
+	member map[string]struct{}
+	debt   float64 // fractional values of rotation carried over to the next Rotate
+	buf    []byte
+}
+
+// NewStringPool creates a StringPool seeded by seed. The alphabet and length have to allow at least twice
+// Cardinality distinct values, so there is always room to rotate in new ones
+func NewStringPool(seed Seed, cfg StringPoolConfig) (*StringPool, error) {
+	if cfg.Cardinality < 1 || cfg.Length < 1 {
+		return nil, invalidArgument("NewStringPool: cardinality %d and length %d", cfg.Cardinality, cfg.Length)
+	}
+	if cfg.Alphabet == nil {
+		cfg.Alphabet = AlphabetBase62
+	}
+	if math.Pow(float64(cfg.Alphabet.Len()), float64(cfg.Length)) < 2*float64(cfg.Cardinality) {
+		return nil, invalidArgument("NewStringPool: %d characters of a %d character alphabet can't hold %d values", cfg.Length, cfg.Alphabet.Len(), cfg.Cardinality)
+	}
+	if !(cfg.Exponent >= 0) || math.IsInf(cfg.Exponent, 0) {
+		return nil, invalidArgument("NewStringPool: exponent %v", cfg.Exponent)
+	}
+	if !(cfg.Rotation >= 0 && cfg.Rotation <= 1) {
+		return nil, invalidArgument("NewStringPool: rotation %v not in [0, 1]", cfg.Rotation)
+	}
+	weights := make([]float64, cfg.Cardinality)
+	for i := range weights {
+		weights[i] = math.Pow(float64(i+1), -cfg.Exponent)
+	}
+	ranks, err := newAliasTable("NewStringPool", weights)
+	if err != nil {
+		return nil, err
+	}
+	p := &StringPool{
+		r:      NewUnsafeXoshiro256ssRNGFromSeed(seed),
+		cfg:    cfg,
+		ranks:  ranks,
+		values: make([]string, cfg.Cardinality),
+		member: make(map[string]struct{}, cfg.Cardinality),
+	}
+	for i := range p.values {
+		p.values[i] = p.newValue()
+		p.member[p.values[i]] = struct{}{}
+	}
+	return p, nil
+}
+
+// newValue draws a value that isn't in the pool
+func (p *StringPool) newValue() string {
+	for {
+		p.buf = append(p.buf[:0], p.cfg.Prefix...)
+		p.buf = p.cfg.Alphabet.Append(p.r, p.buf, p.cfg.Length)
+		if _, ok := p.member[string(p.buf)]; !ok {
+			return string(p.buf)
+		}
+	}
+}
+
+// Next returns a value from the pool, chosen by popularity
+func (p *StringPool) Next() string {
+	return p.values[p.ranks.next(p.r)]
+}
+
+// Rotate replaces Rotation of the values with new ones. The replaced ranks are chosen at random, and the
+// new values take over their popularity
+func (p *StringPool) Rotate() {
+	p.debt += p.cfg.Rotation * float64(p.cfg.Cardinality)
+	n := int(p.debt)
+	p.debt -= float64(n)
+	for _, rank := range SampleInts(p.r, len(p.values), n) {
+		// drawn before the old value leaves, so a replaced value never comes straight back
+		v := p.newValue()
+		delete(p.member, p.values[rank])
+		p.values[rank] = v
+		p.member[v] = struct{}{}
+	}
+}
+
+// Values returns a copy of the values in the pool, most popular first
+func (p *StringPool) Values() []string {
+	return append([]string(nil), p.values...)
+}
@@ -0,0 +1,63 @@
+package fastrand64
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewStringPool_Errors(t *testing.T) {
+	seed := SeedFromString(t.Name())
+	for _, cfg := range []StringPoolConfig{
+		{Cardinality: 0, Length: 4},
+		{Cardinality: 10, Length: 0},
+		{Cardinality: 10, Length: 4, Exponent: -1},
+		{Cardinality: 10, Length: 4, Rotation: 2},
+		{Cardinality: 9, Length: 2, Alphabet: mustAlphabet("abc")},
+	} {
+		p, err := NewStringPool(seed, cfg)
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+		assert.Nil(t, p)
+	}
+}
+
+func Test_StringPool_Cardinality(t *testing.T) {
+	cfg := StringPoolConfig{Cardinality: 100, Length: 3, Alphabet: AlphabetHex, Prefix: "user-", Exponent: 1.1}
+	p, err := NewStringPool(SeedFromString(t.Name()), cfg)
+	assert.NoError(t, err)
+	again, _ := NewStringPool(SeedFromString(t.Name()), cfg)
+
+	counts := map[string]int{}
+	for i := 0; i < 100000; i++ {
+		v := p.Next()
+		assert.Equal(t, v, again.Next())
+		counts[v]++
+	}
+	assert.Equal(t, 100, len(counts))
+	values := p.Values()
+	for _, v := range values {
+		assert.True(t, strings.HasPrefix(v, "user-"))
+		assert.Equal(t, 8, len(v))
+	}
+	// the most popular value beats the least by about 100^1.1
+	assert.Greater(t, counts[values[0]], 50*counts[values[99]])
+}
+
+func Test_StringPool_Rotate(t *testing.T) {
+	p, _ := NewStringPool(SeedFromString(t.Name()), StringPoolConfig{Cardinality: 20, Length: 8, Rotation: 0.1})
+	before := p.Values()
+	p.Rotate()
+	after := p.Values()
+	changed := 0
+	seen := map[string]bool{}
+	for i := range before {
+		if before[i] != after[i] {
+			changed++
+		}
+		assert.False(t, seen[after[i]])
+		seen[after[i]] = true
+	}
+	assert.Equal(t, 2, changed)
+}
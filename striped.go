@@ -0,0 +1,123 @@
+package fastrand64
+
+import (
+	"math/bits"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// cacheLine is the size stripes are padded to, so two cores never fight over the same line
+const cacheLine = 64
+
+type stripe struct {
+	mu  sync.Mutex
+	rng UnsafeXoshiro256ssRNG
+	_   [cacheLine - unsafe.Sizeof(sync.Mutex{}) - unsafe.Sizeof(UnsafeXoshiro256ssRNG{})]byte
+}
+
+// StripedRNG is a fixed array of xoshiro256** generators, each padded to its own cache line and guarded by its own
+// lock. A goroutine picks a stripe by hashing the address of its stack, which is free to compute and differs
+// between goroutines, and moves on to the next stripe if that one is busy.
+//
+// This is a middle ground between the pool, which pays for sync.Pool on every call, and handing each goroutine
+// its own generator. Threadsafe, and usable anywhere an UnsafeRNG is
+type StripedRNG struct {
+	stripes []stripe
+	shift   uint
+}
+
+// NewStripedRNG allocates a StripedRNG, the number of stripes is rounded up to a power of two, and 0
+// means 4 per GOMAXPROCS
+func NewStripedRNG(stripes int) *StripedRNG {
+	if stripes <= 0 {
+		stripes = 4 * runtime.GOMAXPROCS(0)
+	}
+	n := 1 << bits.Len(uint(stripes-1))
+	s := &StripedRNG{stripes: make([]stripe, n), shift: uint(64 - bits.Len(uint(n-1)))}
+	rand.Seed(time.Now().UnixNano())
+	for i := range s.stripes {
+		s.stripes[i].rng.Seed(int64(rand.Uint64()))
+	}
+	return s
+}
+
+// unlock hands the generator off and unlocks the stripe
+func (st *stripe) unlock() {
+	st.rng.handOff()
+	st.mu.Unlock()
+}
+
+// stackHash hashes the address of the calling goroutine's stack, which is free to compute and differs between
+// goroutines, to spread them over slots
+func stackHash() uint64 {
+	var marker byte
+	// goroutine stacks are at least 2KB apart, so the bits below that are mostly call depth
+	return uint64(uintptr(unsafe.Pointer(&marker))>>11) * 0x9E3779B97F4A7C15
+}
+
+// lock locks and returns a stripe, preferring the one the calling goroutine hashes to
+func (s *StripedRNG) lock() *stripe {
+	i := int(stackHash() >> s.shift) // a shift of 64 gives 0, for a single stripe
+	mask := len(s.stripes) - 1
+	for probe := 0; probe < len(s.stripes); probe++ {
+		st := &s.stripes[(i+probe)&mask]
+		if st.mu.TryLock() {
+			return st
+		}
+	}
+	st := &s.stripes[i]
+	st.mu.Lock()
+	return st
+}
+
+// Uint64 returns pseudorandom uint64. Threadsafe
+func (s *StripedRNG) Uint64() uint64 {
+	st := s.lock()
+	x := st.rng.Uint64()
+	st.unlock()
+	return x
+}
+
+// Int63 returns a non-negative pseudorandom int64. Threadsafe
+func (s *StripedRNG) Int63() int64 {
+	return int64(0x7FFFFFFFFFFFFFFF & s.Uint64())
+}
+
+// Uint64n returns an unbiased pseudorandom uint64 in the range [0..n). Threadsafe
+//
+// It panics if n == 0
+func (s *StripedRNG) Uint64n(n uint64) uint64 {
+	if n == 0 {
+		panic("invalid argument to Uint64n")
+	}
+	st := s.lock()
+	x := Uint64n(&st.rng, n)
+	st.unlock()
+	return x
+}
+
+// Intn returns an unbiased pseudorandom int in the range [0..n). Threadsafe
+//
+// It panics if n <= 0
+func (s *StripedRNG) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	return int(s.Uint64n(uint64(n)))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0.0, 1.0). Threadsafe
+func (s *StripedRNG) Float64() float64 {
+	return float64(s.Uint64()>>11) / (1 << 53)
+}
+
+// Read fills a []byte array with random bytes, in the same little endian order as Bytes. Threadsafe
+func (s *StripedRNG) Read(p []byte) []byte {
+	st := s.lock()
+	Bytes(&st.rng, p)
+	st.unlock()
+	return p
+}
@@ -0,0 +1,68 @@
+package fastrand64
+
+import (
+	"sync"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StripedRNG_Layout(t *testing.T) {
+	assert.Equal(t, uintptr(cacheLine), unsafe.Sizeof(stripe{}))
+	assert.Equal(t, 8, len(NewStripedRNG(5).stripes))
+	assert.Equal(t, 1, len(NewStripedRNG(1).stripes))
+	assert.NotEmpty(t, NewStripedRNG(0).stripes)
+}
+
+func Test_StripedRNG(t *testing.T) {
+	for _, n := range []int{1, 16} {
+		s := NewStripedRNG(n)
+		assert.NotEqual(t, s.Uint64(), s.Uint64())
+		for i := 0; i < 1000; i++ {
+			assert.Less(t, s.Uint64n(10), uint64(10))
+			assert.Less(t, s.Intn(10), 10)
+			assert.GreaterOrEqual(t, s.Int63(), int64(0))
+			x := s.Float64()
+			assert.True(t, x >= 0 && x < 1)
+		}
+		assert.Panics(t, func() { s.Uint64n(0) })
+		assert.Equal(t, 13, len(s.Read(make([]byte, 13))))
+		assert.True(t, passesSmokeCheck(s))
+	}
+}
+
+func Test_StripedRNG_Concurrent(t *testing.T) {
+	s := NewStripedRNG(4)
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10000; i++ {
+				s.Uint64()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func Benchmark_StripedRNG_Uint64_Serial(b *testing.B) {
+	s := NewStripedRNG(0)
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r += s.Uint64()
+	}
+	BenchSink = &r
+}
+
+func Benchmark_StripedRNG_Uint64_Parallel(b *testing.B) {
+	s := NewStripedRNG(0)
+	b.RunParallel(func(pb *testing.PB) {
+		var r uint64
+		for pb.Next() {
+			r += s.Uint64()
+		}
+		BenchSink = &r
+	})
+}
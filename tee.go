@@ -0,0 +1,81 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// WriteRandom streams n random bytes to w from a thread unsafe RNG, in chunks from a pooled buffer, so
+// multi-gigabyte payloads never have to be held in memory. It returns the number of bytes written and the
+// first error encountered, like io.CopyN
+func WriteRandom(r UnsafeRNG, w io.Writer, n int64) (int64, error) {
+	return writeRandom(func(p []byte) { Bytes(r, p) }, w, n)
+}
+
+func writeRandom(fill func(p []byte), w io.Writer, n int64) (int64, error) {
+	buf := getChunk()
+	defer putChunk(buf)
+	written := int64(0)
+	for written < n {
+		chunk := *buf
+		if n-written < int64(len(chunk)) {
+			chunk = chunk[:n-written]
+		}
+		fill(chunk)
+		m, err := w.Write(chunk)
+		written += int64(m)
+		if err != nil {
+			return written, err
+		}
+		if m < len(chunk) {
+			return written, io.ErrShortWrite
+		}
+	}
+	return written, nil
+}
+
+// WriteRandom streams n random bytes to w, without allocating the whole payload. Threadsafe
+//
+// A generator is checked out of the pool per chunk rather than for the whole write, so a slow writer
+// doesn't hold one hostage
+func (s *ThreadsafePoolRNG) WriteRandom(w io.Writer, n int64) (int64, error) {
+	return writeRandom(func(p []byte) { s.Read(p) }, w, n)
+}
+
+// MultiWriteRandom writes the same n random bytes to every writer, in chunks, without allocating the whole payload.
+// Threadsafe, it returns the number of bytes written to all of the writers and the first error encountered
+func (s *ThreadsafePoolRNG) MultiWriteRandom(n int64, writers ...io.Writer) (int64, error) {
+	return s.WriteRandom(io.MultiWriter(writers...), n)
+}
+
+// TeeSource wraps an UnsafeRNG and records every value it serves to a sink, as 8 little endian bytes,
+// so the exact random inputs a failing run consumed can be captured and replayed later.
+//
+// It is as unsafe as the RNG it wraps. Wrap slow sinks (files, sockets) in a bufio.Writer
+type TeeSource struct {
+	r    UnsafeRNG
+	sink io.Writer
+	buf  [8]byte
+	err  error
+}
+
+// NewTeeSource creates a TeeSource serving values from r and recording them to sink
+func NewTeeSource(r UnsafeRNG, sink io.Writer) *TeeSource {
+	return &TeeSource{r: r, sink: sink}
+}
+
+// Uint64 returns the next value from the wrapped RNG after recording it. Once the sink fails, recording stops
+// but values are still served, check Err to find out
+func (t *TeeSource) Uint64() uint64 {
+	x := t.r.Uint64()
+	if t.err == nil {
+		binary.LittleEndian.PutUint64(t.buf[:], x)
+		_, t.err = t.sink.Write(t.buf[:])
+	}
+	return x
+}
+
+// Err returns the first error returned by the sink, if any
+func (t *TeeSource) Err() error {
+	return t.err
+}
@@ -0,0 +1,79 @@
+package fastrand64
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SafeRNG_MultiWriteRandom(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	var a, b bytes.Buffer
+	n, err := rng.MultiWriteRandom(100000, &a, &b)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100000), n)
+	assert.Equal(t, 100000, a.Len())
+	assert.Equal(t, a.Bytes(), b.Bytes())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errors.New("boom") }
+
+func Test_SafeRNG_MultiWriteRandom_Error(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	var a bytes.Buffer
+	n, err := rng.MultiWriteRandom(100, &a, failingWriter{})
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), n)
+}
+
+func Test_TeeSource(t *testing.T) {
+	var sink bytes.Buffer
+	tee := NewTeeSource(NewUnsafeRandRNG(1), &sink)
+	expected := NewUnsafeRandRNG(1)
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, expected.Uint64(), tee.Uint64())
+	}
+	assert.NoError(t, tee.Err())
+
+	replay := NewUnsafeRandRNG(1)
+	for i := 0; i < 16; i++ {
+		assert.Equal(t, replay.Uint64(), binary.LittleEndian.Uint64(sink.Next(8)))
+	}
+
+	tee = NewTeeSource(NewUnsafeRandRNG(1), failingWriter{})
+	assert.Equal(t, NewUnsafeRandRNG(1).Uint64(), tee.Uint64())
+	assert.Error(t, tee.Err())
+}
+
+func Test_WriteRandom(t *testing.T) {
+	var a bytes.Buffer
+	n, err := WriteRandom(NewUnsafeRandRNG(1), &a, 1001)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1001), n)
+	assert.Equal(t, Bytes(NewUnsafeRandRNG(1), make([]byte, 1001)), a.Bytes())
+
+	var b bytes.Buffer
+	n, err = NewSyncPoolXoshiro256ssRNG().WriteRandom(&b, 100001)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100001), n)
+	assert.Equal(t, 100001, b.Len())
+
+	n, err = WriteRandom(NewUnsafeRandRNG(1), failingWriter{}, 10)
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), n)
+
+	// a writer that takes nothing and reports no error must not loop forever
+	n, err = WriteRandom(NewUnsafeRandRNG(1), stuckWriter{}, 10)
+	assert.Equal(t, io.ErrShortWrite, err)
+	assert.Equal(t, int64(0), n)
+}
+
+type stuckWriter struct{}
+
+func (stuckWriter) Write(p []byte) (int, error) { return 0, nil }
@@ -0,0 +1,49 @@
+package fastrand64
+
+import "math"
+
+// TieBreak returns priority plus a random epsilon in [0, scale) from a thread unsafe RNG, so entries with equal
+// priorities are ordered fairly at random by a scheduler that would otherwise always favour the same one.
+//
+// Priorities that differ by at least scale keep their order. scale should also be well above the spacing of
+// floats around priority, or the epsilon is rounded away. It panics if scale is negative, NaN or infinite
+func TieBreak(r UnsafeRNG, priority, scale float64) float64 {
+	checkTieBreakScale(scale)
+	return priority + scale*Float64(r)
+}
+
+// TieBreakAll adds a random epsilon in [0, scale) to every priority in place, for re-prioritizing a whole queue
+// at once. A ThreadsafePoolRNG only has a generator checked out once for the whole batch.
+//
+// Pass a seeded generator for reproducible orderings. It panics if scale is negative, NaN or infinite
+func TieBreakAll(r UnsafeRNG, priorities []float64, scale float64) {
+	checkTieBreakScale(scale)
+	if p, ok := r.(*ThreadsafePoolRNG); ok {
+		g, pool := p.get()
+		tieBreakAll(g, priorities, scale)
+		pool.put(g)
+		return
+	}
+	tieBreakAll(r, priorities, scale)
+}
+
+func tieBreakAll(r UnsafeRNG, priorities []float64, scale float64) {
+	for i := range priorities {
+		priorities[i] += scale * Float64(r)
+	}
+}
+
+// TieBreakKeyed returns priority plus an epsilon in [0, scale) that depends only on seed and key, so an entry
+// gets the same tie break however many others were broken before it, or in which order.
+// It panics if scale is negative, NaN or infinite
+func TieBreakKeyed(seed, key uint64, priority, scale float64) float64 {
+	checkTieBreakScale(scale)
+	x := Splitmix64(seed ^ Splitmix64(key))
+	return priority + scale*float64(x>>11)/(1<<53)
+}
+
+func checkTieBreakScale(scale float64) {
+	if !(scale >= 0) || math.IsInf(scale, 0) {
+		panic("invalid tie break scale")
+	}
+}
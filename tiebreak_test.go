@@ -0,0 +1,70 @@
+package fastrand64
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TieBreak(t *testing.T) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	for i := 0; i < 1000; i++ {
+		x := TieBreak(r, 5, 1e-6)
+		assert.GreaterOrEqual(t, x, 5.0)
+		assert.Less(t, x, 5+1e-6)
+	}
+	assert.Equal(t, 5.0, TieBreak(r, 5, 0))
+	assert.Panics(t, func() { TieBreak(r, 5, -1) })
+	assert.Panics(t, func() { TieBreak(r, 5, math.NaN()) })
+	assert.Panics(t, func() { TieBreak(r, 5, math.Inf(1)) })
+}
+
+func Test_TieBreakAll_Fair(t *testing.T) {
+	// three tied entries behind a distinct one, each tied entry should come first about a third of the time
+	rng := NewSyncPoolXoshiro256ssRNG()
+	wins := make([]int, 3)
+	for i := 0; i < 30000; i++ {
+		p := []float64{1, 1, 1, 0}
+		TieBreakAll(rng, p, 1e-3)
+		assert.Less(t, p[3], 1.0)
+		best := 0
+		for j := 1; j < 3; j++ {
+			if p[j] > p[best] {
+				best = j
+			}
+		}
+		wins[best]++
+	}
+	for _, w := range wins {
+		assert.InDelta(t, 10000, w, 500)
+	}
+}
+
+func Test_TieBreakAll_Reproducible(t *testing.T) {
+	a := make([]float64, 100)
+	b := make([]float64, 100)
+	TieBreakAll(NewUnsafeXoshiro256ssRNG(7), a, 1e-9)
+	TieBreakAll(NewUnsafeXoshiro256ssRNG(7), b, 1e-9)
+	assert.Equal(t, a, b)
+	assert.False(t, sort.Float64sAreSorted(a))
+}
+
+func Test_TieBreakKeyed(t *testing.T) {
+	assert.Equal(t, TieBreakKeyed(1, 42, 3, 0.5), TieBreakKeyed(1, 42, 3, 0.5))
+	assert.NotEqual(t, TieBreakKeyed(1, 42, 3, 0.5), TieBreakKeyed(1, 43, 3, 0.5))
+	assert.NotEqual(t, TieBreakKeyed(1, 42, 3, 0.5), TieBreakKeyed(2, 42, 3, 0.5))
+	x := TieBreakKeyed(1, 42, 3, 0.5)
+	assert.GreaterOrEqual(t, x, 3.0)
+	assert.Less(t, x, 3.5)
+}
+
+func Benchmark_TieBreakAll_4096(b *testing.B) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	p := make([]float64, 4096)
+	for i := 0; i < b.N; i++ {
+		TieBreakAll(rng, p, 1e-9)
+	}
+	BenchSink = &p
+}
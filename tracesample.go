@@ -0,0 +1,110 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+	"time"
+)
+
+// ParentSampling is what a span's parent decided, if it has one
+type ParentSampling int
+
+const (
+	// NoParent marks a root span
+	NoParent ParentSampling = iota
+	// ParentSampled means the parent span was sampled
+	ParentSampled
+	// ParentNotSampled means the parent span was dropped
+	ParentNotSampled
+)
+
+// TraceSamplerConfig configures a TraceSampler
+type TraceSamplerConfig struct {
+	// Ratio is the probability of sampling a root trace, in [0, 1]
+	Ratio float64
+	// ParentBased makes spans with a parent follow the parent's decision, so traces are kept or dropped whole
+	ParentBased bool
+	// MaxPerSecond caps the sampled root traces per second with a token bucket that bursts up to one
+	// second's worth, 0 means no cap
+	MaxPerSecond float64
+	// Deterministic decides by hashing the trace id instead of drawing, so every service sampling the same
+	// trace with the same HashSeed and Ratio agrees, without having to propagate the decision
+	Deterministic bool
+	// HashSeed keys the trace id hash of the Deterministic mode
+	HashSeed uint64
+	// Now is the clock for the rate limit, nil means time.Now
+	Now func() time.Time
+}
+
+// TraceSampler decides which spans to record, composing ratio, parent based and rate limited sampling
+// the way tracing SDKs do. Threadsafe
+type TraceSampler struct {
+	rng       *ThreadsafePoolRNG
+	cfg       TraceSamplerConfig
+	threshold uint64 // roots with their top 53 random bits below this are sampled
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewTraceSampler creates a TraceSampler drawing from rng, which is only used when not Deterministic
+func NewTraceSampler(rng *ThreadsafePoolRNG, cfg TraceSamplerConfig) (*TraceSampler, error) {
+	if !(cfg.Ratio >= 0 && cfg.Ratio <= 1) {
+		return nil, invalidArgument("NewTraceSampler: ratio %v not in [0, 1]", cfg.Ratio)
+	}
+	if !(cfg.MaxPerSecond >= 0) || math.IsInf(cfg.MaxPerSecond, 0) {
+		return nil, invalidArgument("NewTraceSampler: MaxPerSecond %v", cfg.MaxPerSecond)
+	}
+	if rng == nil && !cfg.Deterministic {
+		return nil, invalidArgument("NewTraceSampler: nil source")
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	s := &TraceSampler{rng: rng, cfg: cfg, threshold: uint64(cfg.Ratio * (1 << 53))}
+	s.tokens = math.Max(1, cfg.MaxPerSecond)
+	s.last = cfg.Now()
+	return s, nil
+}
+
+// ShouldSample decides whether to record a span of the trace traceID, whose parent decided parent
+func (s *TraceSampler) ShouldSample(traceID [16]byte, parent ParentSampling) bool {
+	if s.cfg.ParentBased && parent != NoParent {
+		return parent == ParentSampled
+	}
+	var x uint64
+	if s.cfg.Deterministic {
+		x = traceIDHash(traceID, s.cfg.HashSeed)
+	} else {
+		x = s.rng.Uint64()
+	}
+	if x>>11 >= s.threshold {
+		return false
+	}
+	return s.cfg.MaxPerSecond == 0 || s.take()
+}
+
+// traceIDHash mixes all 128 bits of the trace id, ids from some tracers are only random in part of them
+func traceIDHash(id [16]byte, seed uint64) uint64 {
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	return Splitmix64(seed ^ Splitmix64(hi) ^ lo)
+}
+
+// take spends a token of the rate limit, if one is left
+func (s *TraceSampler) take() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.cfg.Now()
+	if elapsed := now.Sub(s.last).Seconds(); elapsed > 0 {
+		s.tokens = math.Min(math.Max(1, s.cfg.MaxPerSecond), s.tokens+elapsed*s.cfg.MaxPerSecond)
+		s.last = now
+	}
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
@@ -0,0 +1,90 @@
+package fastrand64
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func traceID(i uint64) [16]byte {
+	var id [16]byte
+	binary.BigEndian.PutUint64(id[8:], i)
+	return id
+}
+
+func Test_NewTraceSampler_Errors(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssRNG()
+	for _, cfg := range []TraceSamplerConfig{{Ratio: -0.1}, {Ratio: 1.1}, {Ratio: 0.5, MaxPerSecond: -1}} {
+		s, err := NewTraceSampler(rng, cfg)
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+		assert.Nil(t, s)
+	}
+	_, err := NewTraceSampler(nil, TraceSamplerConfig{Ratio: 0.5})
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	_, err = NewTraceSampler(nil, TraceSamplerConfig{Ratio: 0.5, Deterministic: true})
+	assert.NoError(t, err)
+}
+
+func Test_TraceSampler_Ratio(t *testing.T) {
+	for _, deterministic := range []bool{false, true} {
+		s, _ := NewTraceSampler(NewSyncPoolXoshiro256ssRNG(), TraceSamplerConfig{Ratio: 0.25, Deterministic: deterministic})
+		n := 0
+		for i := uint64(0); i < 40000; i++ {
+			if s.ShouldSample(traceID(i), NoParent) {
+				n++
+			}
+		}
+		assert.InDelta(t, 10000, n, 400)
+	}
+}
+
+func Test_TraceSampler_ParentBased(t *testing.T) {
+	s, _ := NewTraceSampler(NewSyncPoolXoshiro256ssRNG(), TraceSamplerConfig{Ratio: 0, ParentBased: true})
+	assert.True(t, s.ShouldSample(traceID(1), ParentSampled))
+	assert.False(t, s.ShouldSample(traceID(1), ParentNotSampled))
+	assert.False(t, s.ShouldSample(traceID(1), NoParent))
+
+	// without ParentBased the parent is ignored
+	s, _ = NewTraceSampler(NewSyncPoolXoshiro256ssRNG(), TraceSamplerConfig{Ratio: 0})
+	assert.False(t, s.ShouldSample(traceID(1), ParentSampled))
+}
+
+func Test_TraceSampler_Deterministic(t *testing.T) {
+	cfg := TraceSamplerConfig{Ratio: 0.5, Deterministic: true, HashSeed: 7}
+	a, _ := NewTraceSampler(nil, cfg)
+	b, _ := NewTraceSampler(nil, cfg)
+	lower, _ := NewTraceSampler(nil, TraceSamplerConfig{Ratio: 0.1, Deterministic: true, HashSeed: 7})
+	for i := uint64(0); i < 1000; i++ {
+		assert.Equal(t, a.ShouldSample(traceID(i), NoParent), b.ShouldSample(traceID(i), NoParent))
+		// a trace kept at a low ratio is kept at every higher ratio too
+		if lower.ShouldSample(traceID(i), NoParent) {
+			assert.True(t, a.ShouldSample(traceID(i), NoParent))
+		}
+	}
+}
+
+func Test_TraceSampler_RateLimit(t *testing.T) {
+	now := time.Unix(1000, 0)
+	s, _ := NewTraceSampler(NewSyncPoolXoshiro256ssRNG(), TraceSamplerConfig{
+		Ratio:        1,
+		MaxPerSecond: 10,
+		Now:          func() time.Time { return now },
+	})
+	count := func(n int) int {
+		sampled := 0
+		for i := 0; i < n; i++ {
+			if s.ShouldSample(traceID(uint64(i)), NoParent) {
+				sampled++
+			}
+		}
+		return sampled
+	}
+	assert.Equal(t, 10, count(100))
+	now = now.Add(500 * time.Millisecond)
+	assert.Equal(t, 5, count(100))
+	now = now.Add(time.Hour)
+	assert.Equal(t, 10, count(100))
+}
@@ -0,0 +1,96 @@
+package fastrand64
+
+import (
+	"math"
+	"time"
+)
+
+// TenantWorkload is one tenant's stream for a WorkloadMixer
+type TenantWorkload struct {
+	// Name identifies the tenant, and labels the seed its stream is derived from
+	Name string
+	// Rate is the mean number of events per second, arrivals are Poisson
+	Rate float64
+	// Keys draws the key of each event, for example func(r UnsafeRNG) uint64 { return Uint64n(r, 1000) }.
+	// It is passed the tenant's own generator, so the stream stays reproducible
+	Keys func(r UnsafeRNG) uint64
+}
+
+// WorkloadEvent is one event of a mixed workload
+type WorkloadEvent struct {
+	Tenant int           // index of the tenant in the mixer
+	At     time.Duration // time of the event since the start of the stream
+	Key    uint64
+}
+
+// WorkloadMixer interleaves several tenants' event streams in time order, for testing how shared systems
+// isolate a noisy neighbor from everyone else.
+//
+// Each tenant draws from its own generator, derived from the mixer's seed and the tenant's name, so adding
+// a tenant or turning one up doesn't change what the others do. Not threadsafe
+type WorkloadMixer struct {
+	tenants []TenantWorkload
+	rngs    []*UnsafeXoshiro256ssRNG
+	next    []float64 // next arrival time of each tenant in seconds, +Inf for a tenant with no traffic
+}
+
+// NewWorkloadMixer creates a mixer over tenants, which need distinct names, a non-negative finite rate
+// and a key func
+func NewWorkloadMixer(seed Seed, tenants ...TenantWorkload) (*WorkloadMixer, error) {
+	if len(tenants) == 0 {
+		return nil, invalidArgument("NewWorkloadMixer: no tenants")
+	}
+	m := &WorkloadMixer{
+		tenants: append([]TenantWorkload(nil), tenants...),
+		rngs:    make([]*UnsafeXoshiro256ssRNG, len(tenants)),
+		next:    make([]float64, len(tenants)),
+	}
+	names := make(map[string]bool, len(tenants))
+	active := false
+	for i, t := range tenants {
+		if names[t.Name] {
+			return nil, invalidArgument("NewWorkloadMixer: duplicate tenant %q", t.Name)
+		}
+		names[t.Name] = true
+		if !(t.Rate >= 0) || math.IsInf(t.Rate, 0) {
+			return nil, invalidArgument("NewWorkloadMixer: tenant %q rate %v", t.Name, t.Rate)
+		}
+		if t.Keys == nil {
+			return nil, invalidArgument("NewWorkloadMixer: tenant %q has no key func", t.Name)
+		}
+		m.rngs[i] = NewUnsafeXoshiro256ssRNGFromSeed(seed.DeriveSeed("tenant/" + t.Name))
+		m.next[i] = math.Inf(1)
+		if t.Rate > 0 {
+			m.next[i] = ExpFloat64(m.rngs[i]) / t.Rate
+			active = true
+		}
+	}
+	if !active {
+		return nil, invalidArgument("NewWorkloadMixer: every tenant has a zero rate")
+	}
+	return m, nil
+}
+
+// Next returns the next event across all tenants. It costs O(tenants), which is fine for the handful
+// a test usually mixes
+func (m *WorkloadMixer) Next() WorkloadEvent {
+	i := 0
+	for j := 1; j < len(m.next); j++ {
+		if m.next[j] < m.next[i] {
+			i = j
+		}
+	}
+	r := m.rngs[i]
+	at := m.next[i]
+	m.next[i] += ExpFloat64(r) / m.tenants[i].Rate
+	return WorkloadEvent{Tenant: i, At: time.Duration(at * float64(time.Second)), Key: m.tenants[i].Keys(r)}
+}
+
+// Take returns the next n events
+func (m *WorkloadMixer) Take(n int) []WorkloadEvent {
+	events := make([]WorkloadEvent, n)
+	for i := range events {
+		events[i] = m.Next()
+	}
+	return events
+}
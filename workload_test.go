@@ -0,0 +1,72 @@
+package fastrand64
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func uniformKeys(n uint64) func(r UnsafeRNG) uint64 {
+	return func(r UnsafeRNG) uint64 { return Uint64n(r, n) }
+}
+
+func Test_NewWorkloadMixer_Errors(t *testing.T) {
+	seed := SeedFromString(t.Name())
+	keys := uniformKeys(10)
+	for _, tenants := range [][]TenantWorkload{
+		nil,
+		{{Name: "a", Rate: 1, Keys: keys}, {Name: "a", Rate: 1, Keys: keys}},
+		{{Name: "a", Rate: -1, Keys: keys}},
+		{{Name: "a", Rate: 1}},
+		{{Name: "a", Rate: 0, Keys: keys}},
+	} {
+		m, err := NewWorkloadMixer(seed, tenants...)
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+		assert.Nil(t, m)
+	}
+}
+
+func Test_WorkloadMixer_Rates(t *testing.T) {
+	m, err := NewWorkloadMixer(SeedFromString(t.Name()),
+		TenantWorkload{Name: "quiet", Rate: 100, Keys: uniformKeys(10)},
+		TenantWorkload{Name: "idle", Rate: 0, Keys: uniformKeys(10)},
+		TenantWorkload{Name: "noisy", Rate: 900, Keys: func(r UnsafeRNG) uint64 { return 1000 + Uint64n(r, 10) }},
+	)
+	assert.NoError(t, err)
+	counts := make([]int, 3)
+	var last time.Duration
+	for _, e := range m.Take(100000) {
+		assert.True(t, e.At >= last)
+		last = e.At
+		counts[e.Tenant]++
+		if e.Tenant == 2 {
+			assert.GreaterOrEqual(t, e.Key, uint64(1000))
+		} else {
+			assert.Less(t, e.Key, uint64(10))
+		}
+	}
+	assert.InDelta(t, 10000, counts[0], 400)
+	assert.Equal(t, 0, counts[1])
+	// 1000 events a second in total
+	assert.InDelta(t, 100*time.Second, last, float64(2*time.Second))
+}
+
+func Test_WorkloadMixer_Isolation(t *testing.T) {
+	quiet := TenantWorkload{Name: "quiet", Rate: 100, Keys: uniformKeys(1 << 20)}
+	alone, _ := NewWorkloadMixer(SeedFromString(t.Name()), quiet)
+	mixed, _ := NewWorkloadMixer(SeedFromString(t.Name()),
+		TenantWorkload{Name: "noisy", Rate: 5000, Keys: uniformKeys(10)}, quiet)
+
+	// adding a noisy neighbor leaves the quiet tenant's own stream untouched
+	expected := alone.Take(100)
+	var got []WorkloadEvent
+	for len(got) < 100 {
+		if e := mixed.Next(); e.Tenant == 1 {
+			e.Tenant = 0
+			got = append(got, e)
+		}
+	}
+	assert.Equal(t, expected, got)
+}
@@ -0,0 +1,75 @@
+package fastrand64
+
+// xoroshiro128 is the 128 bit state shared by the xoroshiro128 generators
+type xoroshiro128 struct {
+	owner
+	s0 uint64
+	s1 uint64
+}
+
+// next steps the state, with the 2018 parameters (a=24, b=16, c=37)
+func (r *xoroshiro128) next() {
+	s1 := r.s1 ^ r.s0
+	r.s0 = rol64(r.s0, 24) ^ s1 ^ (s1 << 16)
+	r.s1 = rol64(s1, 37)
+}
+
+// Seed takes a single uint64 and runs it through splitmix64 to seed the 128 bit starting state for the RNG
+func (r *xoroshiro128) Seed(seed int64) {
+	i := 0
+	for r.s0 = 0; r.s0 == 0; i++ {
+		r.s0 = Splitmix64(uint64(seed) + uint64(i))
+	}
+	for r.s1 = 0; r.s1 == 0; i++ {
+		r.s1 = Splitmix64(uint64(seed) + uint64(i))
+	}
+}
+
+// UnsafeXoroshiro128pRNG It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+//
+// xoroshiro128+ keeps half the state of xoshiro256**, for when there are millions of generators, one per simulated
+// entity say, and the memory matters more than the period of 2^128. See https://prng.di.unimi.it/
+//
+// It is the fastest of the xoroshiro family, but its lowest bits are weak, so prefer Float64 and the other
+// functions that use the high bits, or use UnsafeXoroshiro128ssRNG
+type UnsafeXoroshiro128pRNG struct {
+	xoroshiro128
+}
+
+// Uint64 generates a random Uint64, (not thread safe)
+func (r *UnsafeXoroshiro128pRNG) Uint64() uint64 {
+	r.checkOwner("UnsafeXoroshiro128pRNG")
+	result := r.s0 + r.s1
+	r.next()
+	return result
+}
+
+// NewUnsafeXoroshiro128pRNG creates a new Thread unsafe xoroshiro128+ PRNG generator
+func NewUnsafeXoroshiro128pRNG(seed int64) *UnsafeXoroshiro128pRNG {
+	r := &UnsafeXoroshiro128pRNG{}
+	r.Seed(seed)
+	return r
+}
+
+// UnsafeXoroshiro128ssRNG It is unsafe to call UnsafeRNG methods from concurrent goroutines.
+//
+// xoroshiro128** is the all purpose small state member of the xoroshiro family, with no weak bits, see
+// UnsafeXoroshiro128pRNG
+type UnsafeXoroshiro128ssRNG struct {
+	xoroshiro128
+}
+
+// Uint64 generates a random Uint64, (not thread safe)
+func (r *UnsafeXoroshiro128ssRNG) Uint64() uint64 {
+	r.checkOwner("UnsafeXoroshiro128ssRNG")
+	result := rol64(r.s0*5, 7) * 9
+	r.next()
+	return result
+}
+
+// NewUnsafeXoroshiro128ssRNG creates a new Thread unsafe xoroshiro128** PRNG generator
+func NewUnsafeXoroshiro128ssRNG(seed int64) *UnsafeXoroshiro128ssRNG {
+	r := &UnsafeXoroshiro128ssRNG{}
+	r.Seed(seed)
+	return r
+}
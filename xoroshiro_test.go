@@ -0,0 +1,50 @@
+package fastrand64
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnsafeXoroshiro128pRNG_Uint64(t *testing.T) {
+	// from the reference implementation, starting from the state {1, 2}
+	rng := &UnsafeXoroshiro128pRNG{xoroshiro128{s0: 1, s1: 2}}
+	assert.Equal(t, []uint64{0x3, 0x6001030003, 0x20c102c302000c03}, []uint64{rng.Uint64(), rng.Uint64(), rng.Uint64()})
+
+	rng1 := NewUnsafeXoroshiro128pRNG(42)
+	rng2 := NewUnsafeXoroshiro128pRNG(42)
+	for i := 0; i < 256; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+	assert.NotEqual(t, NewUnsafeXoroshiro128pRNG(1).Uint64(), NewUnsafeXoroshiro128pRNG(2).Uint64())
+	assert.True(t, passesSmokeCheck(NewUnsafeXoroshiro128pRNG(0)))
+}
+
+func Test_UnsafeXoroshiro128ssRNG_Uint64(t *testing.T) {
+	rng := &UnsafeXoroshiro128ssRNG{xoroshiro128{s0: 1, s1: 2}}
+	assert.Equal(t, []uint64{0x1680, 0x16c3804380, 0x86b5b3ad00004380}, []uint64{rng.Uint64(), rng.Uint64(), rng.Uint64()})
+
+	rng1 := NewUnsafeXoroshiro128ssRNG(42)
+	rng2 := NewUnsafeXoroshiro128ssRNG(42)
+	for i := 0; i < 256; i++ {
+		assert.Equal(t, rng1.Uint64(), rng2.Uint64())
+	}
+	assert.NotEqual(t, NewUnsafeXoroshiro128ssRNG(1).Uint64(), NewUnsafeXoroshiro128ssRNG(2).Uint64())
+	assert.True(t, passesSmokeCheck(NewUnsafeXoroshiro128ssRNG(0)))
+}
+
+func Test_Xoroshiro128_Size(t *testing.T) {
+	// half the state of xoshiro256**, whether or not the ownership checks add a field
+	assert.Equal(t, unsafe.Sizeof(UnsafeXoshiro256ssRNG{})-16, unsafe.Sizeof(UnsafeXoroshiro128ssRNG{}))
+	assert.Equal(t, unsafe.Sizeof(UnsafeXoroshiro128ssRNG{}), unsafe.Sizeof(UnsafeXoroshiro128pRNG{}))
+}
+
+func Benchmark_UnsafeXoroshiro128ssRNG_Uint64(b *testing.B) {
+	rng := NewUnsafeXoroshiro128ssRNG(1)
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r = rng.Uint64()
+	}
+	BenchSink = &r
+}
@@ -0,0 +1,97 @@
+package fastrand64
+
+import (
+	"math/bits"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ThreadsafeXoshiroPool is ThreadsafePoolRNG specialized to xoshiro256**. The pool hands back
+// *UnsafeXoshiro256ssRNG directly, so each call is a concrete type assertion and an inlined generator step
+// instead of an interface assertion and a dynamic call, which is a noticeable share of the cost of a
+// single Uint64
+type ThreadsafeXoshiroPool struct {
+	pool sync.Pool
+}
+
+// NewThreadsafeXoshiroPool allocates a pool of xoshiro256** generators, seeded the same way as
+// NewSyncPoolXoshiro256ssRNG
+func NewThreadsafeXoshiroPool() *ThreadsafeXoshiroPool {
+	rand.Seed(time.Now().UnixNano())
+	p := &ThreadsafeXoshiroPool{}
+	p.pool.New = func() interface{} {
+		return NewUnsafeXoshiro256ssRNG(int64(rand.Uint64()))
+	}
+	return p
+}
+
+// Uint64 returns pseudorandom uint64. Threadsafe
+func (p *ThreadsafeXoshiroPool) Uint64() uint64 {
+	r := p.pool.Get().(*UnsafeXoshiro256ssRNG)
+	x := r.Uint64()
+	r.handOff()
+	p.pool.Put(r)
+	return x
+}
+
+// Int63 returns a non-negative pseudorandom int64. Threadsafe
+func (p *ThreadsafeXoshiroPool) Int63() int64 {
+	return int64(0x7FFFFFFFFFFFFFFF & p.Uint64())
+}
+
+// Uint64n returns an unbiased pseudorandom uint64 in the range [0..n). Threadsafe
+//
+// It panics if n == 0
+func (p *ThreadsafeXoshiroPool) Uint64n(n uint64) uint64 {
+	if n == 0 {
+		panic("invalid argument to Uint64n")
+	}
+	r := p.pool.Get().(*UnsafeXoshiro256ssRNG)
+	// the same as Uint64n, written out so the generator calls stay direct
+	hi, lo := bits.Mul64(r.Uint64(), n)
+	if lo < n {
+		threshold := -n % n
+		for lo < threshold {
+			hi, lo = bits.Mul64(r.Uint64(), n)
+		}
+	}
+	r.handOff()
+	p.pool.Put(r)
+	return hi
+}
+
+// Intn returns an unbiased pseudorandom int in the range [0..n). Threadsafe
+//
+// It panics if n <= 0
+func (p *ThreadsafeXoshiroPool) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	return int(p.Uint64n(uint64(n)))
+}
+
+// Float64 returns a pseudorandom float64 in the range [0.0, 1.0). Threadsafe
+func (p *ThreadsafeXoshiroPool) Float64() float64 {
+	return float64(p.Uint64()>>11) / (1 << 53)
+}
+
+// Read fills a []byte array with random bytes, in the same little endian order as Bytes. Threadsafe
+func (p *ThreadsafeXoshiroPool) Read(b []byte) []byte {
+	r := p.pool.Get().(*UnsafeXoshiro256ssRNG)
+	Bytes(r, b)
+	r.handOff()
+	p.pool.Put(r)
+	return b
+}
+
+// FillUint64s fills dst with pseudorandom uint64s, checking a generator out of the pool only once. Threadsafe
+func (p *ThreadsafeXoshiroPool) FillUint64s(dst []uint64) []uint64 {
+	r := p.pool.Get().(*UnsafeXoshiro256ssRNG)
+	for i := range dst {
+		dst[i] = r.Uint64()
+	}
+	r.handOff()
+	p.pool.Put(r)
+	return dst
+}
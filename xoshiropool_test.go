@@ -0,0 +1,55 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ThreadsafeXoshiroPool(t *testing.T) {
+	p := NewThreadsafeXoshiroPool()
+	assert.NotEqual(t, p.Uint64(), p.Uint64())
+	for i := 0; i < 4096; i++ {
+		assert.Less(t, p.Uint64n(10), uint64(10))
+		assert.Less(t, p.Intn(7), 7)
+		assert.GreaterOrEqual(t, p.Int63(), int64(0))
+		x := p.Float64()
+		assert.True(t, x >= 0 && x < 1)
+	}
+	assert.Panics(t, func() { p.Uint64n(0) })
+	assert.Panics(t, func() { p.Intn(0) })
+	assert.Equal(t, 13, len(p.Read(make([]byte, 13))))
+	u := p.FillUint64s(make([]uint64, 4))
+	assert.NotEqual(t, u[0], u[1])
+}
+
+func Test_ThreadsafeXoshiroPool_Uniform(t *testing.T) {
+	p := NewThreadsafeXoshiroPool()
+	counts := make([]int, 3)
+	for i := 0; i < 30000; i++ {
+		counts[p.Uint64n(3)]++
+	}
+	for _, c := range counts {
+		assert.InDelta(t, 10000, c, 500)
+	}
+}
+
+func Benchmark_ThreadsafeXoshiroPool_Uint64_Serial(b *testing.B) {
+	p := NewThreadsafeXoshiroPool()
+	var r uint64
+	for i := 0; i < b.N; i++ {
+		r += p.Uint64()
+	}
+	BenchSink = &r
+}
+
+func Benchmark_ThreadsafeXoshiroPool_Uint64_Parallel(b *testing.B) {
+	p := NewThreadsafeXoshiroPool()
+	b.RunParallel(func(pb *testing.PB) {
+		var r uint64
+		for pb.Next() {
+			r += p.Uint64()
+		}
+		BenchSink = &r
+	})
+}
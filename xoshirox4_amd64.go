@@ -0,0 +1,19 @@
+//go:build amd64 && !purego
+
+package fastrand64
+
+// xoshiroX4AVX2 steps all four lanes blocks times, writing 32 bytes per step to dst
+//
+//go:noescape
+func xoshiroX4AVX2(s *[16]uint64, dst *byte, blocks int)
+
+// xoshiroX4Fill fills p, whose length is a multiple of 32, one step of all four lanes at a time
+func xoshiroX4Fill(s *[16]uint64, p []byte) {
+	if !hasAVX2 {
+		xoshiroX4FillGeneric(s, p)
+		return
+	}
+	if len(p) >= 32 {
+		xoshiroX4AVX2(s, &p[0], len(p)/32)
+	}
+}
@@ -0,0 +1,55 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// func xoshiroX4AVX2(s *[16]uint64, dst *byte, blocks int)
+//
+// Y0-Y3 hold s0-s3 of the four lanes. AVX2 has no 64 bit multiply, but the multipliers are 5 and 9,
+// so x*5 is (x<<2)+x and x*9 is (x<<3)+x
+TEXT ·xoshiroX4AVX2(SB), NOSPLIT, $0-24
+	MOVQ s+0(FP), AX
+	MOVQ dst+8(FP), DI
+	MOVQ blocks+16(FP), CX
+	VMOVDQU 0(AX), Y0
+	VMOVDQU 32(AX), Y1
+	VMOVDQU 64(AX), Y2
+	VMOVDQU 96(AX), Y3
+	TESTQ CX, CX
+	JZ done
+
+loop:
+	// result = rotl(s1*5, 7) * 9
+	VPSLLQ $2, Y1, Y4
+	VPADDQ Y1, Y4, Y4
+	VPSLLQ $7, Y4, Y5
+	VPSRLQ $57, Y4, Y4
+	VPOR Y5, Y4, Y4
+	VPSLLQ $3, Y4, Y5
+	VPADDQ Y5, Y4, Y4
+	VMOVDQU Y4, 0(DI)
+
+	// t = s1 << 17
+	VPSLLQ $17, Y1, Y6
+
+	VPXOR Y0, Y2, Y2 // s2 ^= s0
+	VPXOR Y1, Y3, Y3 // s3 ^= s1
+	VPXOR Y2, Y1, Y1 // s1 ^= s2
+	VPXOR Y3, Y0, Y0 // s0 ^= s3
+	VPXOR Y6, Y2, Y2 // s2 ^= t
+
+	// s3 = rotl(s3, 45)
+	VPSLLQ $45, Y3, Y7
+	VPSRLQ $19, Y3, Y3
+	VPOR Y7, Y3, Y3
+
+	ADDQ $32, DI
+	DECQ CX
+	JNZ loop
+
+done:
+	VMOVDQU Y0, 0(AX)
+	VMOVDQU Y1, 32(AX)
+	VMOVDQU Y2, 64(AX)
+	VMOVDQU Y3, 96(AX)
+	VZEROUPPER
+	RET
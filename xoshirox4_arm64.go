@@ -0,0 +1,16 @@
+//go:build arm64 && !purego
+
+package fastrand64
+
+// xoshiroX4NEON steps all four lanes blocks times, writing 32 bytes per step to dst
+//
+//go:noescape
+func xoshiroX4NEON(s *[16]uint64, dst *byte, blocks int)
+
+// xoshiroX4Fill fills p, whose length is a multiple of 32, one step of all four lanes at a time.
+// NEON is part of every arm64 cpu, so there is nothing to detect
+func xoshiroX4Fill(s *[16]uint64, p []byte) {
+	if len(p) >= 32 {
+		xoshiroX4NEON(s, &p[0], len(p)/32)
+	}
+}
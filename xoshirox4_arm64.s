@@ -0,0 +1,68 @@
+//go:build arm64 && !purego
+
+#include "textflag.h"
+
+// func xoshiroX4NEON(s *[16]uint64, dst *byte, blocks int)
+//
+// NEON registers hold two lanes each, so s0-s3 of the four lanes take V0-V7: s0 in V0 (lanes 0 and 1) and
+// V1 (lanes 2 and 3), s1 in V2 and V3, s2 in V4 and V5, s3 in V6 and V7. Like the AVX2 version, x*5 is
+// (x<<2)+x and x*9 is (x<<3)+x
+TEXT ·xoshiroX4NEON(SB), NOSPLIT, $0-24
+	MOVD s+0(FP), R0
+	MOVD dst+8(FP), R1
+	MOVD blocks+16(FP), R2
+	ADD  $64, R0, R3
+	VLD1 (R0), [V0.D2, V1.D2, V2.D2, V3.D2]
+	VLD1 (R3), [V4.D2, V5.D2, V6.D2, V7.D2]
+	CBZ  R2, done
+
+loop:
+	// result = rotl(s1*5, 7) * 9, lanes 0 and 1 in V16, lanes 2 and 3 in V17
+	VSHL  $2, V2.D2, V16.D2
+	VADD  V2.D2, V16.D2, V16.D2
+	VSHL  $7, V16.D2, V18.D2
+	VUSHR $57, V16.D2, V16.D2
+	VORR  V18.B16, V16.B16, V16.B16
+	VSHL  $3, V16.D2, V18.D2
+	VADD  V18.D2, V16.D2, V16.D2
+
+	VSHL  $2, V3.D2, V17.D2
+	VADD  V3.D2, V17.D2, V17.D2
+	VSHL  $7, V17.D2, V19.D2
+	VUSHR $57, V17.D2, V17.D2
+	VORR  V19.B16, V17.B16, V17.B16
+	VSHL  $3, V17.D2, V19.D2
+	VADD  V19.D2, V17.D2, V17.D2
+
+	VST1.P [V16.D2, V17.D2], 32(R1)
+
+	// t = s1 << 17
+	VSHL $17, V2.D2, V20.D2
+	VSHL $17, V3.D2, V21.D2
+
+	VEOR V0.B16, V4.B16, V4.B16   // s2 ^= s0
+	VEOR V1.B16, V5.B16, V5.B16
+	VEOR V2.B16, V6.B16, V6.B16   // s3 ^= s1
+	VEOR V3.B16, V7.B16, V7.B16
+	VEOR V4.B16, V2.B16, V2.B16   // s1 ^= s2
+	VEOR V5.B16, V3.B16, V3.B16
+	VEOR V6.B16, V0.B16, V0.B16   // s0 ^= s3
+	VEOR V7.B16, V1.B16, V1.B16
+	VEOR V20.B16, V4.B16, V4.B16  // s2 ^= t
+	VEOR V21.B16, V5.B16, V5.B16
+
+	// s3 = rotl(s3, 45)
+	VSHL  $45, V6.D2, V22.D2
+	VUSHR $19, V6.D2, V6.D2
+	VORR  V22.B16, V6.B16, V6.B16
+	VSHL  $45, V7.D2, V23.D2
+	VUSHR $19, V7.D2, V7.D2
+	VORR  V23.B16, V7.B16, V7.B16
+
+	SUBS $1, R2, R2
+	BNE  loop
+
+done:
+	VST1 [V0.D2, V1.D2, V2.D2, V3.D2], (R0)
+	VST1 [V4.D2, V5.D2, V6.D2, V7.D2], (R3)
+	RET
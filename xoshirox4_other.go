@@ -0,0 +1,8 @@
+//go:build !(amd64 || arm64) || purego
+
+package fastrand64
+
+// xoshiroX4Fill fills p, whose length is a multiple of 32, one step of all four lanes at a time
+func xoshiroX4Fill(s *[16]uint64, p []byte) {
+	xoshiroX4FillGeneric(s, p)
+}
@@ -0,0 +1,99 @@
+package fastrand64
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// plainRNG hides any bulk methods, so the generic code paths are used
+type plainRNG struct{ r UnsafeRNG }
+
+func (p plainRNG) Uint64() uint64 { return p.r.Uint64() }
+
+func Test_Xoshiro256ssX4_Lanes(t *testing.T) {
+	lanes := make([]*UnsafeXoshiro256ssRNG, 4)
+	for l := range lanes {
+		lanes[l] = NewUnsafeXoshiro256ssRNG(42)
+		for j := 0; j < l; j++ {
+			lanes[l].jump()
+		}
+	}
+	assert.NotEqual(t, lanes[0].State(), lanes[1].State())
+
+	r := NewUnsafeXoshiro256ssX4RNG(42)
+	for i := 0; i < 100; i++ {
+		for l := range lanes {
+			assert.Equal(t, lanes[l].Uint64(), r.Uint64())
+		}
+	}
+}
+
+func Test_Xoshiro256ssX4_FillMatchesGeneric(t *testing.T) {
+	for _, skip := range []int{0, 1, 3} {
+		for _, n := range []int{0, 5, 8, 31, 32, 33, 64, 1000, 4096} {
+			a := NewUnsafeXoshiro256ssX4RNG(7)
+			b := NewUnsafeXoshiro256ssX4RNG(7)
+			for i := 0; i < skip; i++ {
+				a.Uint64()
+				b.Uint64()
+			}
+			assert.Equal(t, Bytes(plainRNG{b}, make([]byte, n)), Bytes(a, make([]byte, n)), "skip %d n %d", skip, n)
+			assert.Equal(t, b.Uint64(), a.Uint64())
+
+			assert.Equal(t, FillUint64s(plainRNG{b}, make([]uint64, n)), FillUint64s(a, make([]uint64, n)))
+			assert.Equal(t, FillInt64s(plainRNG{b}, make([]int64, n)), FillInt64s(a, make([]int64, n)))
+			assert.Equal(t, FillFloat64s(plainRNG{b}, make([]float64, n)), FillFloat64s(a, make([]float64, n)))
+			assert.Equal(t, b.Uint64(), a.Uint64())
+		}
+	}
+}
+
+func Test_Xoshiro256ssX4_FillKernel(t *testing.T) {
+	// whichever kernel this machine uses has to match the portable one
+	a := NewUnsafeXoshiro256ssX4RNG(9)
+	b := NewUnsafeXoshiro256ssX4RNG(9)
+	pa := make([]byte, 32*100)
+	pb := make([]byte, 32*100)
+	xoshiroX4Fill(&a.s, pa)
+	xoshiroX4FillGeneric(&b.s, pb)
+	assert.Equal(t, pb, pa)
+	assert.Equal(t, b.s, a.s)
+}
+
+func Test_SafeRNG_Xoshiro256ssX4(t *testing.T) {
+	rng := NewSyncPoolXoshiro256ssX4RNG()
+	b := rng.Bytes(1000)
+	assert.Equal(t, 1000, len(b))
+	assert.NotEqual(t, make([]byte, 8), b[992:])
+}
+
+func Benchmark_UnsafeXoshiro256ssRNG_Bytes_64KB(b *testing.B) {
+	r := NewUnsafeXoshiro256ssRNG(1)
+	buf := make([]byte, 64<<10)
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		Bytes(r, buf)
+	}
+	BenchSink = &buf
+}
+
+func Benchmark_UnsafeXoshiro256ssX4RNG_Bytes_64KB(b *testing.B) {
+	r := NewUnsafeXoshiro256ssX4RNG(1)
+	buf := make([]byte, 64<<10)
+	b.SetBytes(int64(len(buf)))
+	for i := 0; i < b.N; i++ {
+		Bytes(r, buf)
+	}
+	BenchSink = &buf
+}
+
+func Benchmark_UnsafeXoshiro256ssX4RNG_FillUint64s_8K(b *testing.B) {
+	r := NewUnsafeXoshiro256ssX4RNG(1)
+	dst := make([]uint64, 8<<10)
+	b.SetBytes(int64(len(dst) * 8))
+	for i := 0; i < b.N; i++ {
+		FillUint64s(r, dst)
+	}
+	BenchSink = &dst
+}
@@ -0,0 +1,76 @@
+package fastrand64
+
+import "math"
+
+// Zipf generates Zipf distributed values, P(k) is proportional to (v + k) ** (-s) for k in [0, imax].
+// The parameters match math/rand.Zipf, and the same rejection-inversion method is used, see
+// Hörmann & Derflinger, "Rejection-inversion to generate variates from monotone discrete distributions" (1996)
+//
+// A Zipf backed by a ThreadsafePoolRNG is safe to use from concurrent goroutines, and checks a generator
+// out of the pool once per value. Backed by anything else it is only as safe as its source
+type Zipf struct {
+	r            UnsafeRNG
+	imax         float64
+	v            float64
+	q            float64
+	s            float64
+	oneminusQ    float64
+	oneminusQinv float64
+	hxm          float64
+	hx0minusHxm  float64
+}
+
+func (z *Zipf) h(x float64) float64 {
+	return math.Exp(z.oneminusQ*math.Log(z.v+x)) * z.oneminusQinv
+}
+
+func (z *Zipf) hinv(x float64) float64 {
+	return math.Exp(z.oneminusQinv*math.Log(z.oneminusQ*x)) - z.v
+}
+
+// NewZipf returns a Zipf generator drawing from r, it requires s > 1 and v >= 1
+func NewZipf(r UnsafeRNG, s float64, v float64, imax uint64) (*Zipf, error) {
+	if r == nil {
+		return nil, invalidArgument("NewZipf: nil source")
+	}
+	if !(s > 1) || !(v >= 1) || math.IsInf(s, 0) || math.IsInf(v, 0) {
+		return nil, invalidArgument("NewZipf: need s > 1 and v >= 1, got s=%v v=%v", s, v)
+	}
+	z := &Zipf{r: r}
+	z.imax = float64(imax)
+	z.v = v
+	z.q = s
+	z.oneminusQ = 1.0 - z.q
+	z.oneminusQinv = 1.0 / z.oneminusQ
+	z.hxm = z.h(z.imax + 0.5)
+	z.hx0minusHxm = z.h(0.5) - math.Exp(math.Log(z.v)*(-z.q)) - z.hxm
+	z.s = 1 - z.hinv(z.h(1.5)-math.Exp(-z.q*math.Log(z.v+1.0)))
+	return z, nil
+}
+
+// Uint64 returns a value drawn from the Zipf distribution
+func (z *Zipf) Uint64() uint64 {
+	if p, ok := z.r.(*ThreadsafePoolRNG); ok {
+		r, pool := p.get()
+		x := z.next(r)
+		pool.put(r)
+		return x
+	}
+	return z.next(z.r)
+}
+
+func (z *Zipf) next(r UnsafeRNG) uint64 {
+	var k float64
+	for {
+		ur := z.hxm + Float64(r)*z.hx0minusHxm
+		x := z.hinv(ur)
+		k = math.Floor(x + 0.5)
+		if k-x <= z.s {
+			break
+		}
+		if ur >= z.h(k+0.5)-math.Exp(-math.Log(k+z.v)*z.q) {
+			break
+		}
+	}
+	return uint64(k)
+}
@@ -0,0 +1,44 @@
+package fastrand64
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewZipf_Errors(t *testing.T) {
+	rng := NewUnsafeXoshiro256ssRNG(1)
+	for _, c := range []struct{ s, v float64 }{{1, 1}, {0.5, 1}, {2, 0.5}} {
+		z, err := NewZipf(rng, c.s, c.v, 100)
+		assert.True(t, errors.Is(err, ErrInvalidArgument))
+		assert.Nil(t, z)
+	}
+	z, err := NewZipf(nil, 2, 1, 100)
+	assert.True(t, errors.Is(err, ErrInvalidArgument))
+	assert.Nil(t, z)
+}
+
+func Test_Zipf_Uint64(t *testing.T) {
+	z, err := NewZipf(NewUnsafeXoshiro256ssRNG(1), 2, 1, 1000000)
+	assert.NoError(t, err)
+	const n = 100000
+	zeros := 0
+	for i := 0; i < n; i++ {
+		x := z.Uint64()
+		assert.LessOrEqual(t, x, uint64(1000000))
+		if x == 0 {
+			zeros++
+		}
+	}
+	// P(0) = 1 / zeta(2) = 6 / pi^2
+	assert.InDelta(t, 0.6079, float64(zeros)/n, 0.01)
+}
+
+func Test_Zipf_SafeRNG(t *testing.T) {
+	z, err := NewZipf(NewSyncPoolXoshiro256ssRNG(), 1.5, 2, 10)
+	assert.NoError(t, err)
+	for i := 0; i < 4096; i++ {
+		assert.LessOrEqual(t, z.Uint64(), uint64(10))
+	}
+}